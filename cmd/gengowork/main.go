@@ -39,15 +39,21 @@ func main() {
 	misc.DotEnv(".env", false /*noisy*/)
 
 	fColor := misc.LookupEnv(cmd.EnvColor, false)
-	fLogLevel := misc.LookupEnv(cmd.EnvLogLevel, int(logging.LInfo))
+	fLogLevel := misc.LookupEnv(cmd.EnvLogLevel, logging.LInfo.String())
+	levels, errLevels := logging.ParseLevelSpec(fLogLevel)
 
-	//nolint:gosec // log level does not overflow here
-	opts := []logging.CreateOptions{logging.OptFlags(log.Lshortfile), logging.OptLevel(logging.Level(fLogLevel))}
+	opts := []logging.CreateOptions{logging.OptFlags(log.Lshortfile), logging.OptLevelSpec(levels)}
 	if fColor {
 		opts = append(opts, logging.OptColor())
 	}
 
-	logger = logging.Create(opts...)
+	logger = logging.Create(opts...).Named("gengowork")
+
+	if errLevels != nil {
+		errFinal = errors.Join(errLevels, errUsage)
+
+		return
+	}
 
 	errFinal = genGoWork()
 }
@@ -85,15 +85,22 @@ func main() {
 	misc.DotEnv(".env", false /* noisy */)
 
 	fColor := misc.LookupEnv(cmd.EnvColor, false)
-	fLogLevel := misc.LookupEnv(cmd.EnvLogLevel, int(logging.LInfo))
+	fLogLevel := misc.LookupEnv(cmd.EnvLogLevel, logging.LInfo.String())
+	levels, errLevels := logging.ParseLevelSpec(fLogLevel)
 
-	//nolint:gosec // log level fits in uint8.
-	opts := []logging.CreateOptions{logging.OptFlags(log.Lshortfile), logging.OptLevel(logging.Level(fLogLevel))}
+	opts := []logging.CreateOptions{logging.OptFlags(log.Lshortfile), logging.OptLevelSpec(levels)}
 	if fColor {
 		opts = append(opts, logging.OptColor())
 	}
 
-	logger = logging.Create(opts...)
+	logger = logging.Create(opts...).Named("t0changelog")
+
+	if errLevels != nil {
+		errFinal = errors.Join(errLevels, errUsage)
+
+		return
+	}
+
 	cfg := flagset.String("config", ".commitlintrc.yml", "path to commitlint config file")
 	title := flagset.String("title", "", "release title; new version and date will be added")
 	tagPrefixRaw := flagset.String("tagprefixes", "", "comma separated prefixes to find tags, i.e $PREFIXv1.0.0")
@@ -52,15 +52,29 @@ func main() {
 	misc.DotEnv(".env", false /* noisy */)
 
 	fColor := misc.LookupEnv(cmd.EnvColor, false)
-	fLogLevel := misc.LookupEnv(cmd.EnvLogLevel, int(logging.LInfo))
+	fLogLevel := misc.LookupEnv(cmd.EnvLogLevel, logging.LInfo.String())
+	// level 0 logs everything with -fix and only errors without it, see below
+	quiet := fLogLevel == "0"
 
-	//nolint:gosec // level does not overflow here.
-	opts := []logging.CreateOptions{logging.OptFlags(log.Lshortfile), logging.OptLevel(logging.Level(fLogLevel))}
+	if quiet {
+		fLogLevel = logging.LDebug.String()
+	}
+
+	levels, errLevels := logging.ParseLevelSpec(fLogLevel)
+
+	opts := []logging.CreateOptions{logging.OptFlags(log.Lshortfile), logging.OptLevelSpec(levels)}
 	if fColor {
 		opts = append(opts, logging.OptColor())
 	}
 
-	logger = logging.Create(opts...)
+	logger = logging.Create(opts...).Named("t0copyright")
+
+	if errLevels != nil {
+		errFinal = errors.Join(errLevels, errUsage)
+
+		return
+	}
+
 	fFix := flagset.Bool("fix", false, "write header to files; requires -comment. (default false)")
 	fShebang := flagset.Bool("shebang", false, "preserve first line of file, append header after. (default false)")
 	fComment := flagset.String("comment", "", "comment token, prepended to header lines. (required if -fix)")
@@ -97,8 +111,8 @@ func main() {
 		return
 	}
 
-	if fLogLevel == 0 && !*fFix {
-		// without -fix and explicit level only print errors
+	if quiet && !*fFix {
+		// without -fix and level 0 only print errors
 		logger.SetLevel(logging.LError)
 	}
 
@@ -61,15 +61,22 @@ func main() {
 	misc.DotEnv(".env", false /* noisy */)
 
 	fColor := misc.LookupEnv(cmd.EnvColor, false)
-	fLogLevel := misc.LookupEnv(cmd.EnvLogLevel, int(logging.LInfo))
+	fLogLevel := misc.LookupEnv(cmd.EnvLogLevel, logging.LInfo.String())
+	levels, errLevels := logging.ParseLevelSpec(fLogLevel)
 
-	//nolint:gosec // level does not overflow here.
-	opts := []logging.CreateOptions{logging.OptFlags(log.Lshortfile), logging.OptLevel(logging.Level(fLogLevel))}
+	opts := []logging.CreateOptions{logging.OptFlags(log.Lshortfile), logging.OptLevelSpec(levels)}
 	if fColor {
 		opts = append(opts, logging.OptColor())
 	}
 
-	logger = logging.Create(opts...)
+	logger = logging.Create(opts...).Named("t0filer")
+
+	if errLevels != nil {
+		errFinal = errors.Join(errLevels, errUsage)
+
+		return
+	}
+
 	fConfig := flagset.String("config", "", "path to config file (required)")
 	fCommitL := flagset.Bool("commit", false, "apply changes (default: false)")
 	fCommitS := flagset.Bool("c", false, "apply changes (default: false)")
@@ -49,15 +49,22 @@ func main() {
 	misc.DotEnv(".env", false /*noisy*/)
 
 	fColor := misc.LookupEnv(cmd.EnvColor, false)
-	fLogLevel := misc.LookupEnv(cmd.EnvLogLevel, int(logging.LInfo))
+	fLogLevel := misc.LookupEnv(cmd.EnvLogLevel, logging.LInfo.String())
+	levels, errLevels := logging.ParseLevelSpec(fLogLevel)
 
-	//nolint:gosec // log level fits in uint8.
-	opts := []logging.CreateOptions{logging.OptFlags(log.Lshortfile), logging.OptLevel(logging.Level(fLogLevel))}
+	opts := []logging.CreateOptions{logging.OptFlags(log.Lshortfile), logging.OptLevelSpec(levels)}
 	if fColor {
 		opts = append(opts, logging.OptColor())
 	}
 
-	logger = logging.Create(opts...)
+	logger = logging.Create(opts...).Named("t0runner")
+
+	if errLevels != nil {
+		errFinal = errors.Join(errLevels, internal.ErrUsage)
+
+		return
+	}
+
 	fVerShort := flagset.Bool("v", false, "print version and exit")
 	fVerLong := flagset.Bool("version", false, "print version and exit")
 	fConfig := flagset.String("config", "", "config file")
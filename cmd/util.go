@@ -94,7 +94,8 @@ func OverwriteFile(filePath string, data []byte) error {
 func EnvVarUsage() string {
 	format := `environment variables:
 - %s     toggle logger colored output (default: false)
-- %s  1 - 5, 1 is debug. The higher the less logs (default: 2)`
+- %s  debug, info, warn, error, fatal or 1 - 5, 1 is debug. The higher the less logs (default: info)
+               per command levels may follow, comma separated: info,t0runner=debug`
 
 	return fmt.Sprintf(format, EnvColor, EnvLogLevel)
 }
@@ -0,0 +1,167 @@
+// Copyright 2024 Raphael Thomazella. All rights reserved.
+// Use of this source code is governed by the BSD-3-Clause
+// license that can be found in the LICENSE file and online
+// at https://opensource.org/license/BSD-3-clause.
+
+package httpmisc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tcodes0/go/misc"
+)
+
+// margin before expiry when tokens are refreshed.
+const tokenExpiryMargin = 10 * time.Second
+
+// adds credentials to requests sent by a Client, see SetClientOptions.
+type Authenticator interface {
+	Authenticate(req *http.Request) error
+}
+
+// an Authenticator function.
+type AuthFunc func(req *http.Request) error
+
+var _ Authenticator = AuthFunc(nil)
+
+// implementation of Authenticator.
+func (f AuthFunc) Authenticate(req *http.Request) error {
+	return f(req)
+}
+
+// sends "Authorization: Bearer <token>".
+func BearerAuth(token string) AuthFunc {
+	return HeaderAuth("Authorization", "Bearer "+token)
+}
+
+// sends "Authorization: token <token>", used by GitHub.
+func TokenAuth(token string) AuthFunc {
+	return HeaderAuth("Authorization", "token "+token)
+}
+
+// sends http basic authentication.
+func BasicAuth(user, password string) AuthFunc {
+	return func(req *http.Request) error {
+		req.SetBasicAuth(user, password)
+
+		return nil
+	}
+}
+
+// sends the header key with value, like "X-API-Key: <key>".
+func HeaderAuth(key, value string) AuthFunc {
+	return func(req *http.Request) error {
+		req.Header.Set(key, value)
+
+		return nil
+	}
+}
+
+// sends the query parameter key with value, like "?api_key=<key>".
+func QueryAuth(key, value string) AuthFunc {
+	return func(req *http.Request) error {
+		query := req.URL.Query()
+		query.Set(key, value)
+		req.URL.RawQuery = query.Encode()
+
+		return nil
+	}
+}
+
+// OAuth2 client credentials grant; tokens are requested from TokenURL when
+// first needed and again when about to expire. Safe for concurrent use.
+type ClientCredentials struct {
+	expiry time.Time
+	// used to request tokens, http.DefaultClient if nil.
+	Client       *http.Client
+	token        string
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	mu           sync.Mutex
+}
+
+var _ Authenticator = (*ClientCredentials)(nil)
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// implementation of Authenticator, sends the token as bearer.
+func (cc *ClientCredentials) Authenticate(req *http.Request) error {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	if cc.token == "" || (!cc.expiry.IsZero() && time.Now().After(cc.expiry.Add(-tokenExpiryMargin))) {
+		err := cc.refresh(req)
+		if err != nil {
+			return err
+		}
+	}
+
+	req.Header.Set("Authorization", "Bearer "+cc.token)
+
+	return nil
+}
+
+// requests a new token, using req's context.
+func (cc *ClientCredentials) refresh(req *http.Request) error {
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(cc.Scopes) > 0 {
+		form.Set("scope", strings.Join(cc.Scopes, " "))
+	}
+
+	tokenReq, err := http.NewRequestWithContext(req.Context(), http.MethodPost, cc.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return misc.Wrap(err, "creating token request")
+	}
+
+	tokenReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	tokenReq.SetBasicAuth(url.QueryEscape(cc.ClientID), url.QueryEscape(cc.ClientSecret))
+
+	res, err := misc.Default(cc.Client, http.DefaultClient).Do(tokenReq)
+	if err != nil {
+		return misc.Wrap(err, "requesting token")
+	}
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return misc.Wrap(err, "reading token response")
+	}
+
+	if res.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("requesting token: %w", &HTTPError{Status: res.StatusCode, Header: res.Header, Body: data})
+	}
+
+	token := tokenResponse{}
+
+	err = json.Unmarshal(data, &token)
+	if err != nil {
+		return misc.Wrap(err, "unmarshalling token")
+	}
+
+	if token.AccessToken == "" {
+		return errors.New("empty access token")
+	}
+
+	cc.token = token.AccessToken
+	cc.expiry = time.Time{}
+
+	if token.ExpiresIn > 0 {
+		cc.expiry = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
+
+	return nil
+}
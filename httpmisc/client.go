@@ -10,9 +10,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"io"
-	"net"
 	"net/http"
 	"reflect"
 	"time"
@@ -21,18 +19,39 @@ import (
 	"github.com/tcodes0/go/misc"
 )
 
+// name of loggers used by this package, see logging.Logger.Named.
+const LoggerName = "httpmisc"
+
 // an http client.
 type Client struct {
-	apiKey     string
-	baseURL    string
 	httpClient *http.Client
+	redaction  *logging.Redaction
+	auth       Authenticator
+	limiter    *limiter
+	retry      Retry
+	baseURL    string
 	userAgent  string
 	timeout    time.Duration
 }
 
 // options for setting a client; some options are required.
 type SetClientOptions struct {
-	Client    *http.Client
+	// copied, its transport is kept unless Transport is set.
+	Client *http.Client
+	// used to send requests, overrides the Client transport.
+	Transport http.RoundTripper
+	// used if neither Transport nor the Client transport are set.
+	TransportOptions *TransportOptions
+	// adds credentials to requests; defaults to BearerAuth with APIKey,
+	// requests are not authenticated if both are empty.
+	Auth Authenticator
+	// secrets removed from logged headers, urls and bodies; defaults to
+	// logging.DefaultRedaction, an empty redaction logs everything.
+	Redaction *logging.Redaction
+	// retries of failed requests, requests are not retried if nil.
+	Retry *Retry
+	// limits requests sent, requests are not limited if nil.
+	RateLimit *RateLimit
 	UserAgent string
 	BaseURL   string
 	APIKey    string
@@ -43,35 +62,45 @@ type SetClientOptions struct {
 func (c *Client) Init(opts *SetClientOptions) error {
 	opts = misc.Default(opts, &SetClientOptions{})
 
-	if opts.UserAgent == "" || opts.BaseURL == "" || opts.APIKey == "" {
-		return errors.New("user agent, base url, api key are required")
+	if opts.UserAgent == "" || opts.BaseURL == "" {
+		return errors.New("user agent, base url are required")
 	}
 
-	opts.Client = misc.Default(opts.Client, &http.Client{})
 	opts.Timeout = misc.Default(opts.Timeout, misc.Seconds(15))
-	c.httpClient = opts.Client
+	httpClient := *misc.Default(opts.Client, &http.Client{})
+	c.httpClient = &httpClient
 
-	dialer := &net.Dialer{Timeout: opts.Timeout}
-	c.httpClient.Transport = &http.Transport{Dial: dialer.Dial}
+	if opts.Transport != nil {
+		c.httpClient.Transport = opts.Transport
+	} else if c.httpClient.Transport == nil {
+		c.httpClient.Transport = opts.TransportOptions.transport(opts.Timeout)
+	}
 
 	c.baseURL = opts.BaseURL
-	c.apiKey = opts.APIKey
 	c.userAgent = opts.UserAgent
 	c.timeout = opts.Timeout
+	c.redaction = misc.Default(opts.Redaction, logging.DefaultRedaction())
+	c.retry = misc.Default(opts.Retry, &Retry{}).withDefaults()
+	c.limiter = newLimiter(opts.RateLimit)
+	c.auth = opts.Auth
+
+	if c.auth == nil && opts.APIKey != "" {
+		c.auth = BearerAuth(opts.APIKey)
+	}
 
 	return nil
 }
 
-// sends a request with a body and headers.
+// sends a request with a body and headers, retrying it if configured,
+// see SetClientOptions.Retry. The context deadline covers all attempts.
+// Responses with status 300 or above return their body and an *HTTPError.
 func (c Client) Request(ctx context.Context, method, resource string, body any, headers http.Header) (*http.Response, []byte, error) {
 	if c.httpClient == nil {
 		return nil, nil, errors.New("nil client")
 	}
 
-	tBody := reflect.TypeOf(body)
-
-	if tBody.Kind() != reflect.Ptr || tBody.Elem().Kind() != reflect.Struct {
-		return nil, nil, errors.New("body must be a struct pointer")
+	if tBody := reflect.TypeOf(body); body != nil && (tBody.Kind() != reflect.Ptr || tBody.Elem().Kind() != reflect.Struct) {
+		return nil, nil, errors.New("body must be a struct pointer or nil")
 	}
 
 	if _, ok := ctx.Deadline(); !ok {
@@ -80,46 +109,88 @@ func (c Client) Request(ctx context.Context, method, resource string, body any,
 		defer cancel()
 	}
 
-	req, err := makeRequest(ctx, method, c.baseURL+resource, body)
+	logger := logging.FromContextOr(ctx, &logging.Logger{}).Named(LoggerName).WithCtx(ctx)
+
+	for attempt := 1; ; attempt++ {
+		logger.DebugData(map[string]any{"attempt": attempt, "max": max(c.retry.MaxAttempts, 1)}, "attempt")
+
+		res, data, err := c.attempt(ctx, method, resource, body, headers)
+
+		delay, retry := c.retry.delay(ctx, attempt, method, res, err)
+		if !retry {
+			return res, data, err
+		}
+
+		logger.WarnData(map[string]any{"attempt": attempt, "delay": delay, "error": err}, "retrying request")
+
+		if errWait := wait(ctx, delay); errWait != nil {
+			return res, data, errors.Join(err, misc.Wrap(errWait, "waiting to retry"))
+		}
+	}
+}
+
+// sends a request once, the response body is read and closed.
+func (c Client) attempt(ctx context.Context, method, resource string, body any, headers http.Header) (*http.Response, []byte, error) {
+	logger := logging.FromContextOr(ctx, &logging.Logger{}).Named(LoggerName).WithCtx(ctx)
+
+	req, err := makeRequest(ctx, method, c.baseURL+resource, body, c.redaction)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	req.Header = misc.Default(headers, http.Header{})
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	// headers are reused by retries
+	req.Header = misc.Default(headers.Clone(), http.Header{})
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Add("User-Agent", c.userAgent)
 
-	logger := logging.FromContext(ctx)
+	if c.auth != nil {
+		err = c.auth.Authenticate(req)
+		if err != nil {
+			req.Body.Close()
+
+			return nil, nil, misc.Wrap(err, "authenticating")
+		}
+	}
+
+	logger.Debugf("headers %v", c.redaction.Header(req.Header))
 
-	logger.Debugf("headers %v", req.Header)
+	release, err := c.limiter.wait(ctx, req.URL.Host)
+	if err != nil {
+		// the request is not sent, its body must be closed
+		req.Body.Close()
+
+		return nil, nil, err
+	}
+	defer release()
 
 	res, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, nil, misc.Wrap(err, "doing request")
+		return nil, nil, &transportError{err: misc.Wrap(err, "doing request")}
 	}
 	defer res.Body.Close()
 
-	logger.Debugf("status %d", res.StatusCode)
+	c.limiter.observe(req.URL.Host, res.Header)
 
-	if res.StatusCode >= http.StatusMultipleChoices {
-		return res, nil, fmt.Errorf("status code: %d", res.StatusCode)
-	}
+	logger.Debugf("status %d", res.StatusCode)
 
 	data, err := io.ReadAll(res.Body)
 	if err != nil {
 		return res, nil, misc.Wrap(err, "reading response body")
 	}
 
-	logger.Debugf("response %s", string(data))
+	logger.Debugf("response %s", c.redaction.String(string(data)))
+
+	if res.StatusCode >= http.StatusMultipleChoices {
+		return res, data, &HTTPError{Status: res.StatusCode, Header: res.Header, Body: data}
+	}
 
 	return res, data, nil
 }
 
-func makeRequest(ctx context.Context, method, url string, body any) (*http.Request, error) {
-	logger := logging.FromContext(ctx)
+func makeRequest(ctx context.Context, method, url string, body any, redaction *logging.Redaction) (*http.Request, error) {
+	logger := logging.FromContextOr(ctx, &logging.Logger{}).Named(LoggerName).WithCtx(ctx)
 
-	logger.Debugf("url %s", url)
+	logger.Debugf("url %s", redaction.String(url))
 
 	if body == nil {
 		req, err := http.NewRequestWithContext(ctx, method, url, http.NoBody)
@@ -135,17 +206,10 @@ func makeRequest(ctx context.Context, method, url string, body any) (*http.Reque
 		return nil, misc.Wrap(err, "marshalling body")
 	}
 
-	logger.Debugf("body %v", body)
-
-	reader, writer := io.Pipe()
-	go func() {
-		//nolint:govet // scope ok
-		var err error
-		_, err = io.Copy(writer, bytes.NewReader(data))
-		writer.CloseWithError(err)
-	}()
+	logger.Debugf("body %s", redaction.String(string(data)))
 
-	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	// a bytes reader sets GetBody and the content length
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(data))
 	if err != nil {
 		return nil, misc.Wrap(err, "creating request")
 	}
@@ -0,0 +1,83 @@
+// Copyright 2024 Raphael Thomazella. All rights reserved.
+// Use of this source code is governed by the BSD-3-Clause
+// license that can be found in the LICENSE file and online
+// at https://opensource.org/license/BSD-3-clause.
+
+package httpmisc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/tcodes0/go/misc"
+)
+
+// a response with status 300 or above, returned by Client.Request and Do.
+type HTTPError struct {
+	// the decoded body, see DoErr; nil if not decoded.
+	Payload any
+	Header  http.Header
+	Body    []byte
+	Status  int
+}
+
+var _ error = (*HTTPError)(nil)
+
+func (e *HTTPError) Error() string {
+	return "status code: " + strconv.Itoa(e.Status)
+}
+
+// sends a request with a json body, see Client.Request, and decodes a
+// successful json response into Resp; an empty response is a zero Resp.
+// Error responses are returned as *HTTPError.
+func Do[Resp any](ctx context.Context, c Client, method, path string, body any) (*Resp, error) {
+	_, data, err := c.Request(ctx, method, path, body, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return decode[Resp](data)
+}
+
+// like Do, and decodes error responses into the *HTTPError Payload as a
+// *Payload. Payload is left nil if the error body is not valid json for it.
+func DoErr[Resp, Payload any](ctx context.Context, c Client, method, path string, body any) (*Resp, error) {
+	resp, err := Do[Resp](ctx, c, method, path, body)
+
+	httpErr := &HTTPError{}
+	if errors.As(err, &httpErr) && httpErr.Payload == nil {
+		if payload, errDecode := decode[Payload](httpErr.Body); errDecode == nil {
+			httpErr.Payload = payload
+		}
+	}
+
+	return resp, err
+}
+
+// returns the decoded payload of an *HTTPError in err's chain, see DoErr.
+func ErrorPayload[Payload any](err error) (*Payload, bool) {
+	httpErr := &HTTPError{}
+	if !errors.As(err, &httpErr) {
+		return nil, false
+	}
+
+	payload, ok := httpErr.Payload.(*Payload)
+
+	return payload, ok
+}
+
+func decode[T any](data []byte) (*T, error) {
+	out := new(T)
+
+	if len(data) > 0 {
+		err := json.Unmarshal(data, out)
+		if err != nil {
+			return nil, misc.Wrap(err, "unmarshalling response")
+		}
+	}
+
+	return out, nil
+}
@@ -0,0 +1,164 @@
+// Copyright 2024 Raphael Thomazella. All rights reserved.
+// Use of this source code is governed by the BSD-3-Clause
+// license that can be found in the LICENSE file and online
+// at https://opensource.org/license/BSD-3-clause.
+
+package httpmisc_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tcodes0/go/httpmisc"
+)
+
+// responds with the request's authorization header, x-api-key header and query.
+func echoAuthServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+		_, _ = writer.Write([]byte(req.Header.Get("Authorization") + "|" + req.Header.Get("X-Api-Key") + "|" + req.URL.RawQuery))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestClientAuth(t *testing.T) {
+	t.Parallel()
+
+	server := echoAuthServer(t)
+
+	tests := []struct {
+		auth     httpmisc.Authenticator
+		name     string
+		apiKey   string
+		expected string
+	}{
+		{name: "none", expected: "||a=1"},
+		{name: "api key", apiKey: "key", expected: "Bearer key||a=1"},
+		{name: "bearer", auth: httpmisc.BearerAuth("abc"), apiKey: "ignored", expected: "Bearer abc||a=1"},
+		{name: "token", auth: httpmisc.TokenAuth("abc"), expected: "token abc||a=1"},
+		{name: "basic", auth: httpmisc.BasicAuth("user", "pass"), expected: "Basic dXNlcjpwYXNz||a=1"},
+		{name: "header", auth: httpmisc.HeaderAuth("X-API-Key", "abc"), expected: "|abc|a=1"},
+		{name: "query", auth: httpmisc.QueryAuth("api_key", "abc"), expected: "||a=1&api_key=abc"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert := require.New(t)
+			client := httpmisc.Client{}
+			assert.NoError(client.Init(&httpmisc.SetClientOptions{
+				UserAgent: "test", BaseURL: server.URL, APIKey: test.apiKey, Auth: test.auth,
+			}))
+
+			_, data, err := client.Get(context.Background(), "/?a=1", nil, nil)
+			assert.NoError(err)
+			assert.Equal(test.expected, string(data))
+		})
+	}
+}
+
+func TestClientCredentials(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	issued := &atomic.Int32{}
+	tokens := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+		user, pass, ok := req.BasicAuth()
+		if !ok || user != "id" || pass != "secret" || req.FormValue("grant_type") != "client_credentials" {
+			writer.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		// expires within the refresh margin, every request refreshes
+		if req.FormValue("scope") == "short" {
+			issued.Add(1)
+			_, _ = writer.Write([]byte(`{"access_token":"short","token_type":"bearer","expires_in":1}`))
+
+			return
+		}
+
+		issued.Add(1)
+		_, _ = writer.Write([]byte(`{"access_token":"long","token_type":"bearer","expires_in":3600}`))
+	}))
+	t.Cleanup(tokens.Close)
+
+	server := echoAuthServer(t)
+	client := httpmisc.Client{}
+	assert.NoError(client.Init(&httpmisc.SetClientOptions{
+		UserAgent: "test",
+		BaseURL:   server.URL,
+		Auth:      &httpmisc.ClientCredentials{TokenURL: tokens.URL, ClientID: "id", ClientSecret: "secret", Scopes: []string{"read"}},
+	}))
+
+	for range 3 {
+		_, data, err := client.Get(context.Background(), "/", nil, nil)
+		assert.NoError(err)
+		assert.Equal("Bearer long||", string(data))
+	}
+
+	assert.Equal(int32(1), issued.Load())
+
+	assert.NoError(client.Init(&httpmisc.SetClientOptions{
+		UserAgent: "test",
+		BaseURL:   server.URL,
+		Auth:      &httpmisc.ClientCredentials{TokenURL: tokens.URL, ClientID: "id", ClientSecret: "secret", Scopes: []string{"short"}},
+	}))
+
+	for range 2 {
+		_, data, err := client.Get(context.Background(), "/", nil, nil)
+		assert.NoError(err)
+		assert.Equal("Bearer short||", string(data))
+	}
+
+	assert.Equal(int32(3), issued.Load())
+
+	assert.NoError(client.Init(&httpmisc.SetClientOptions{
+		UserAgent: "test",
+		BaseURL:   server.URL,
+		Auth:      &httpmisc.ClientCredentials{TokenURL: tokens.URL, ClientID: "id", ClientSecret: "wrong"},
+	}))
+
+	_, _, err := client.Get(context.Background(), "/", nil, nil)
+	assert.ErrorContains(err, "status code: 401")
+}
+
+//nolint:paralleltest // counts goroutines
+func TestClientAuthError(t *testing.T) {
+	assert := require.New(t)
+	server := echoAuthServer(t)
+	replayable := false
+	client := httpmisc.Client{}
+	assert.NoError(client.Init(&httpmisc.SetClientOptions{
+		UserAgent: "test",
+		BaseURL:   server.URL,
+		Auth: httpmisc.AuthFunc(func(req *http.Request) error {
+			replayable = req.GetBody != nil && req.ContentLength > 0
+
+			return errors.New("no token")
+		}),
+	}))
+
+	before := runtime.NumGoroutine()
+
+	for range 50 {
+		_, _, err := client.Post(context.Background(), "/", &body{Name: "a"}, nil)
+		assert.ErrorContains(err, "authenticating")
+	}
+
+	assert.True(replayable)
+	assert.Eventually(func() bool {
+		return runtime.NumGoroutine() <= before+5
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,109 @@
+// Copyright 2024 Raphael Thomazella. All rights reserved.
+// Use of this source code is governed by the BSD-3-Clause
+// license that can be found in the LICENSE file and online
+// at https://opensource.org/license/BSD-3-clause.
+
+package httpmisc_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tcodes0/go/httpmisc"
+	"github.com/tcodes0/go/logging"
+)
+
+type body struct {
+	Name string `json:"name"`
+}
+
+func TestClientRequestContext(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		_, _ = writer.Write([]byte(`{"ok":true}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client := httpmisc.Client{}
+	assert.NoError(client.Init(&httpmisc.SetClientOptions{UserAgent: "test", BaseURL: server.URL, APIKey: "key"}))
+
+	// no logger in context
+	_, data, err := client.Post(context.Background(), "/", &body{Name: "a"}, nil)
+	assert.NoError(err)
+	assert.Equal(`{"ok":true}`, string(data))
+
+	out := &bytes.Buffer{}
+	logger := logging.Create(logging.OptWriter(out), logging.OptFlags(0), logging.OptLevel(logging.LDebug))
+	ctx := logging.WithRequestID(logger.WithContext(context.Background()), "req-1")
+
+	_, _, err = client.Post(ctx, "/", &body{Name: "a"}, nil)
+	assert.NoError(err)
+	assert.Contains(out.String(), "requestId=req-1")
+}
+
+func TestClientRequestRedaction(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		_, _ = writer.Write([]byte(`{"token":"from-server"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	out := &bytes.Buffer{}
+	logger := logging.Create(logging.OptWriter(out), logging.OptFlags(0), logging.OptLevel(logging.LDebug))
+	ctx := logger.WithContext(context.Background())
+
+	client := httpmisc.Client{}
+	assert.NoError(client.Init(&httpmisc.SetClientOptions{UserAgent: "test", BaseURL: server.URL, APIKey: "secret-key"}))
+
+	_, _, err := client.Post(ctx, "/?api_key=query-key", &body{Name: "a"}, http.Header{"Cookie": {"session=abc"}})
+	assert.NoError(err)
+	assert.NotContains(out.String(), "secret-key")
+	assert.NotContains(out.String(), "query-key")
+	assert.NotContains(out.String(), "session=abc")
+	assert.NotContains(out.String(), "from-server")
+	assert.Contains(out.String(), logging.Redacted)
+
+	out.Reset()
+
+	client = httpmisc.Client{}
+	assert.NoError(client.Init(&httpmisc.SetClientOptions{
+		UserAgent: "test", BaseURL: server.URL, APIKey: "secret-key", Redaction: &logging.Redaction{},
+	}))
+
+	_, _, err = client.Post(ctx, "/", &body{Name: "a"}, nil)
+	assert.NoError(err)
+	assert.Contains(out.String(), "secret-key")
+}
+
+func TestRoundtripRedaction(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		writer.Header().Set("Set-Cookie", "session=abc")
+	}))
+	t.Cleanup(server.Close)
+
+	out := &bytes.Buffer{}
+	logger := logging.Create(logging.OptWriter(out), logging.OptFlags(0), logging.OptLevel(logging.LDebug))
+	client := &http.Client{Transport: httpmisc.Roundtrip{Transport: &http.Transport{}, Logger: logger}}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, http.NoBody)
+	assert.NoError(err)
+	req.Header.Set("Authorization", "Bearer abc")
+
+	res, err := client.Do(req)
+	assert.NoError(err)
+	assert.NoError(res.Body.Close())
+	assert.NotContains(out.String(), "Bearer abc")
+	assert.NotContains(out.String(), "session=abc")
+	assert.Contains(out.String(), logging.Redacted)
+}
@@ -0,0 +1,81 @@
+// Copyright 2024 Raphael Thomazella. All rights reserved.
+// Use of this source code is governed by the BSD-3-Clause
+// license that can be found in the LICENSE file and online
+// at https://opensource.org/license/BSD-3-clause.
+
+package httpmisc_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tcodes0/go/httpmisc"
+)
+
+type user struct {
+	Name string `json:"name"`
+	ID   int    `json:"id"`
+}
+
+type apiError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func TestDo(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/users/1":
+			_, _ = writer.Write([]byte(`{"id":1,"name":"ana"}`))
+		case "/empty":
+			writer.WriteHeader(http.StatusNoContent)
+		case "/invalid":
+			_, _ = writer.Write([]byte(`{`))
+		default:
+			writer.Header().Set("X-Error", "1")
+			writer.WriteHeader(http.StatusNotFound)
+			_, _ = writer.Write([]byte(`{"code":"not_found","message":"no such user"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client := httpmisc.Client{}
+	assert.NoError(client.Init(&httpmisc.SetClientOptions{UserAgent: "test", BaseURL: server.URL, APIKey: "key"}))
+
+	got, err := httpmisc.Do[user](context.Background(), client, http.MethodGet, "/users/1", nil)
+	assert.NoError(err)
+	assert.Equal(&user{ID: 1, Name: "ana"}, got)
+
+	got, err = httpmisc.Do[user](context.Background(), client, http.MethodDelete, "/empty", &body{Name: "a"})
+	assert.NoError(err)
+	assert.Equal(&user{}, got)
+
+	_, err = httpmisc.Do[user](context.Background(), client, http.MethodGet, "/invalid", nil)
+	assert.ErrorContains(err, "unmarshalling response")
+
+	got, err = httpmisc.Do[user](context.Background(), client, http.MethodGet, "/users/2", nil)
+	assert.Nil(got)
+
+	httpErr := &httpmisc.HTTPError{}
+	assert.True(errors.As(err, &httpErr))
+	assert.Equal(http.StatusNotFound, httpErr.Status)
+	assert.Equal("1", httpErr.Header.Get("X-Error"))
+	assert.JSONEq(`{"code":"not_found","message":"no such user"}`, string(httpErr.Body))
+	assert.Nil(httpErr.Payload)
+	assert.EqualError(err, "status code: 404")
+
+	_, err = httpmisc.DoErr[user, apiError](context.Background(), client, http.MethodGet, "/users/2", nil)
+	payload, ok := httpmisc.ErrorPayload[apiError](err)
+	assert.True(ok)
+	assert.Equal(&apiError{Code: "not_found", Message: "no such user"}, payload)
+
+	_, ok = httpmisc.ErrorPayload[apiError](errors.New("other"))
+	assert.False(ok)
+}
@@ -0,0 +1,190 @@
+// Copyright 2024 Raphael Thomazella. All rights reserved.
+// Use of this source code is governed by the BSD-3-Clause
+// license that can be found in the LICENSE file and online
+// at https://opensource.org/license/BSD-3-clause.
+
+package httpmisc_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tcodes0/go/httpmisc"
+)
+
+func limitedClient(t *testing.T, url string, limit *httpmisc.RateLimit) httpmisc.Client {
+	t.Helper()
+
+	client := httpmisc.Client{}
+	require.NoError(t, client.Init(&httpmisc.SetClientOptions{UserAgent: "test", BaseURL: url, RateLimit: limit}))
+
+	return client
+}
+
+func okServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestClientRateLimit(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	server := okServer(t)
+	client := limitedClient(t, server.URL, &httpmisc.RateLimit{Rate: 20, Burst: 2})
+	start := time.Now()
+
+	for range 6 {
+		_, _, err := client.Get(context.Background(), "/", nil, nil)
+		assert.NoError(err)
+	}
+
+	// 2 burst, then 4 at 50ms intervals
+	assert.GreaterOrEqual(time.Since(start), 190*time.Millisecond)
+
+	client = limitedClient(t, server.URL, &httpmisc.RateLimit{Rate: 1})
+	start = time.Now()
+
+	_, _, err := client.Get(context.Background(), "/", nil, nil)
+	assert.NoError(err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, _, err = client.Get(ctx, "/", nil, nil)
+	assert.ErrorContains(err, "waiting for rate limit")
+	assert.ErrorIs(err, context.DeadlineExceeded)
+	assert.Less(time.Since(start), time.Second)
+}
+
+func TestClientRateLimitPerHost(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	servers := []*httptest.Server{okServer(t), okServer(t)}
+
+	for _, perHost := range []bool{true, false} {
+		// hosts differ by port
+		client := limitedClient(t, "http://", &httpmisc.RateLimit{Rate: 1, PerHost: perHost})
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+
+		_, _, err := client.Get(ctx, servers[0].Listener.Addr().String()+"/", nil, nil)
+		assert.NoError(err)
+
+		_, _, err = client.Get(ctx, servers[1].Listener.Addr().String()+"/", nil, nil)
+		if perHost {
+			assert.NoError(err)
+		} else {
+			assert.ErrorIs(err, context.DeadlineExceeded)
+		}
+
+		cancel()
+	}
+}
+
+func TestClientMaxInFlight(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	inFlight := &atomic.Int32{}
+	peak := &atomic.Int32{}
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+
+		for {
+			old := peak.Load()
+			if current <= old || peak.CompareAndSwap(old, current) {
+				break
+			}
+		}
+
+		time.Sleep(20 * time.Millisecond)
+	}))
+	t.Cleanup(server.Close)
+
+	client := limitedClient(t, server.URL, &httpmisc.RateLimit{MaxInFlight: 2})
+	wg := sync.WaitGroup{}
+
+	for range 8 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			_, _, err := client.Get(context.Background(), "/", nil, nil)
+			assert.NoError(err)
+		}()
+	}
+
+	wg.Wait()
+	assert.Equal(int32(2), peak.Load())
+}
+
+func TestClientRateLimitHeaders(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	calls := &atomic.Int32{}
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) == 1 {
+			writer.Header().Set("X-RateLimit-Remaining", "0")
+			writer.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(2*time.Second).Unix(), 10))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client := limitedClient(t, server.URL, &httpmisc.RateLimit{})
+
+	_, _, err := client.Get(context.Background(), "/", nil, nil)
+	assert.NoError(err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, _, err = client.Get(ctx, "/", nil, nil)
+	assert.ErrorIs(err, context.DeadlineExceeded)
+	assert.Equal(int32(1), calls.Load())
+
+	start := time.Now()
+	_, _, err = client.Get(context.Background(), "/", nil, nil)
+	assert.NoError(err)
+	assert.Greater(time.Since(start), 500*time.Millisecond)
+	assert.Equal(int32(2), calls.Load())
+}
+
+//nolint:paralleltest // counts goroutines
+func TestClientRateLimitCancelled(t *testing.T) {
+	assert := require.New(t)
+	server := okServer(t)
+	client := limitedClient(t, server.URL, &httpmisc.RateLimit{Rate: 0.001})
+
+	_, _, err := client.Post(context.Background(), "/", &body{Name: "a"}, nil)
+	assert.NoError(err)
+
+	before := runtime.NumGoroutine()
+
+	for range 50 {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, _, err = client.Post(ctx, "/", &body{Name: "a"}, nil)
+		assert.ErrorIs(err, context.Canceled)
+	}
+
+	assert.Eventually(func() bool {
+		return runtime.NumGoroutine() <= before+5
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,55 @@
+// Copyright 2024 Raphael Thomazella. All rights reserved.
+// Use of this source code is governed by the BSD-3-Clause
+// license that can be found in the LICENSE file and online
+// at https://opensource.org/license/BSD-3-clause.
+
+package httpmisc_test
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tcodes0/go/httpmisc"
+	"github.com/tcodes0/go/logging"
+)
+
+//nolint:paralleltest // sets the standard logger output
+func TestRecovererFallback(t *testing.T) {
+	assert := require.New(t)
+	out := &bytes.Buffer{}
+	writer := log.Writer()
+
+	log.SetOutput(out)
+	t.Cleanup(func() { log.SetOutput(writer) })
+
+	handler := httpmisc.Recoverer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic("boom")
+	}))
+	recorder := httptest.NewRecorder()
+
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(http.StatusInternalServerError, recorder.Code)
+	assert.Contains(out.String(), "recover=boom")
+	assert.Contains(out.String(), "TestRecovererFallback")
+}
+
+func TestRecoverer(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	out := &bytes.Buffer{}
+	logger := logging.Create(logging.OptWriter(out), logging.OptJSON())
+	handler := httpmisc.Recoverer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic("boom")
+	}))
+	recorder := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	handler.ServeHTTP(recorder, req.WithContext(logger.WithContext(req.Context())))
+	assert.Equal(http.StatusInternalServerError, recorder.Code)
+	assert.Contains(out.String(), `"recover":"boom"`)
+}
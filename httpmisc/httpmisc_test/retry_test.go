@@ -0,0 +1,178 @@
+// Copyright 2024 Raphael Thomazella. All rights reserved.
+// Use of this source code is governed by the BSD-3-Clause
+// license that can be found in the LICENSE file and online
+// at https://opensource.org/license/BSD-3-clause.
+
+package httpmisc_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tcodes0/go/httpmisc"
+	"github.com/tcodes0/go/logging"
+)
+
+// responds with statuses in order, then 200.
+func statusServer(t *testing.T, calls *atomic.Int32, header http.Header, statuses ...int) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		call := int(calls.Add(1))
+		if call <= len(statuses) {
+			for key, values := range header {
+				writer.Header()[key] = values
+			}
+
+			writer.WriteHeader(statuses[call-1])
+
+			return
+		}
+
+		_, _ = writer.Write([]byte(`{"ok":true}`))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func retryClient(t *testing.T, url string, retry *httpmisc.Retry) httpmisc.Client {
+	t.Helper()
+
+	client := httpmisc.Client{}
+	require.NoError(t, client.Init(&httpmisc.SetClientOptions{UserAgent: "test", BaseURL: url, APIKey: "key", Retry: retry}))
+
+	return client
+}
+
+func TestClientRetry(t *testing.T) {
+	t.Parallel()
+
+	retry := &httpmisc.Retry{MaxAttempts: 4, BaseDelay: time.Millisecond}
+
+	tests := []struct {
+		retry    *httpmisc.Retry
+		name     string
+		method   string
+		statuses []int
+		calls    int32
+		fails    bool
+	}{
+		{name: "success", retry: retry, method: http.MethodGet, calls: 1},
+		{name: "retryable", retry: retry, method: http.MethodGet, statuses: []int{503, 429, 502}, calls: 4},
+		{name: "exhausted", retry: retry, method: http.MethodPut, statuses: []int{504, 504, 504, 504}, calls: 4, fails: true},
+		{name: "not retryable", retry: retry, method: http.MethodGet, statuses: []int{500}, calls: 1, fails: true},
+		{name: "not idempotent", retry: retry, method: http.MethodPost, statuses: []int{503}, calls: 1, fails: true},
+		{name: "disabled", method: http.MethodGet, statuses: []int{503}, calls: 1, fails: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert := require.New(t)
+			calls := &atomic.Int32{}
+			server := statusServer(t, calls, nil, test.statuses...)
+			client := retryClient(t, server.URL, test.retry)
+
+			_, data, err := client.Request(context.Background(), test.method, "/", &body{Name: "a"}, nil)
+			assert.Equal(test.calls, calls.Load())
+
+			if test.fails {
+				assert.Error(err)
+
+				return
+			}
+
+			assert.NoError(err)
+			assert.Equal(`{"ok":true}`, string(data))
+		})
+	}
+}
+
+func TestClientRetryTransport(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	client := retryClient(t, url, &httpmisc.Retry{MaxAttempts: 3, BaseDelay: time.Millisecond})
+	out := &bytes.Buffer{}
+	logger := logging.Create(logging.OptWriter(out), logging.OptFlags(0))
+
+	_, _, err := client.Get(logger.WithContext(context.Background()), "/", &body{}, nil)
+	assert.Error(err)
+	assert.Equal(2, strings.Count(out.String(), "retrying request"))
+
+	out.Reset()
+
+	_, _, err = client.Post(logger.WithContext(context.Background()), "/", &body{}, nil)
+	assert.Error(err)
+	assert.Empty(out.String())
+}
+
+func TestClientRetryRequestErrors(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	calls := &atomic.Int32{}
+	server := statusServer(t, calls, nil)
+	out := &bytes.Buffer{}
+	ctx := logging.Create(logging.OptWriter(out), logging.OptFlags(0)).WithContext(context.Background())
+	retry := &httpmisc.Retry{MaxAttempts: 3, BaseDelay: time.Millisecond}
+	client := retryClient(t, server.URL, retry)
+
+	_, _, err := client.Get(ctx, "/", &struct{ C chan int }{}, nil)
+	assert.ErrorContains(err, "marshalling body")
+
+	client = httpmisc.Client{}
+	assert.NoError(client.Init(&httpmisc.SetClientOptions{
+		UserAgent: "test",
+		BaseURL:   server.URL,
+		Retry:     retry,
+		Auth:      httpmisc.AuthFunc(func(*http.Request) error { return errors.New("no token") }),
+	}))
+
+	_, _, err = client.Get(ctx, "/", nil, nil)
+	assert.ErrorContains(err, "authenticating")
+
+	assert.NotContains(out.String(), "retrying request")
+	assert.Zero(calls.Load())
+}
+
+func TestClientRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	calls := &atomic.Int32{}
+	server := statusServer(t, calls, http.Header{"Retry-After": {"1"}}, http.StatusTooManyRequests)
+	client := retryClient(t, server.URL, &httpmisc.Retry{MaxAttempts: 2, BaseDelay: time.Millisecond})
+
+	start := time.Now()
+	_, _, err := client.Get(context.Background(), "/", &body{}, nil)
+	assert.NoError(err)
+	assert.GreaterOrEqual(time.Since(start), time.Second)
+	assert.Equal(int32(2), calls.Load())
+
+	// waiting would pass the deadline
+	calls.Store(0)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start = time.Now()
+	_, _, err = client.Get(ctx, "/", &body{}, nil)
+	assert.Error(err)
+	assert.Less(time.Since(start), time.Second)
+	assert.Equal(int32(1), calls.Load())
+}
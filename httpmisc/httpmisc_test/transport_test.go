@@ -0,0 +1,120 @@
+// Copyright 2024 Raphael Thomazella. All rights reserved.
+// Use of this source code is governed by the BSD-3-Clause
+// license that can be found in the LICENSE file and online
+// at https://opensource.org/license/BSD-3-clause.
+
+package httpmisc_test
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tcodes0/go/httpmisc"
+)
+
+type countingTransport struct {
+	calls atomic.Int32
+}
+
+func (c *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.calls.Add(1)
+
+	//nolint:wrapcheck // test
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestClientTransport(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+		_, _ = writer.Write([]byte(req.Proto))
+	}))
+	t.Cleanup(server.Close)
+
+	caller := &countingTransport{}
+	httpClient := &http.Client{Transport: caller}
+	client := httpmisc.Client{}
+	assert.NoError(client.Init(&httpmisc.SetClientOptions{UserAgent: "test", BaseURL: server.URL, Client: httpClient}))
+
+	_, _, err := client.Get(context.Background(), "/", nil, nil)
+	assert.NoError(err)
+	assert.Equal(int32(1), caller.calls.Load())
+	assert.Same(caller, httpClient.Transport)
+
+	override := &countingTransport{}
+	assert.NoError(client.Init(&httpmisc.SetClientOptions{
+		UserAgent: "test", BaseURL: server.URL, Client: httpClient, Transport: override,
+	}))
+
+	_, _, err = client.Get(context.Background(), "/", nil, nil)
+	assert.NoError(err)
+	assert.Equal(int32(1), caller.calls.Load())
+	assert.Equal(int32(1), override.calls.Load())
+	assert.Same(caller, httpClient.Transport)
+}
+
+func TestClientTransportOptions(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+		_, _ = writer.Write([]byte(req.Proto))
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	assert.NoError(os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0o600))
+
+	config, err := httpmisc.LoadTLS(caFile, "", "")
+	assert.NoError(err)
+
+	client := httpmisc.Client{}
+	assert.NoError(client.Init(&httpmisc.SetClientOptions{
+		UserAgent: "test", BaseURL: server.URL, TransportOptions: &httpmisc.TransportOptions{TLS: config},
+	}))
+
+	_, data, err := client.Get(context.Background(), "/", nil, nil)
+	assert.NoError(err)
+	assert.Equal("HTTP/2.0", string(data))
+
+	proxied := &atomic.Int32{}
+	assert.NoError(client.Init(&httpmisc.SetClientOptions{
+		UserAgent: "test",
+		BaseURL:   server.URL,
+		TransportOptions: &httpmisc.TransportOptions{
+			TLS:          config,
+			DisableHTTP2: true,
+			Proxy: func(*http.Request) (*url.URL, error) {
+				proxied.Add(1)
+
+				//nolint:nilnil // no proxy
+				return nil, nil
+			},
+		},
+	}))
+
+	_, data, err = client.Get(context.Background(), "/", nil, nil)
+	assert.NoError(err)
+	assert.Equal("HTTP/1.1", string(data))
+	assert.Equal(int32(1), proxied.Load())
+
+	// untrusted without the ca
+	assert.NoError(client.Init(&httpmisc.SetClientOptions{UserAgent: "test", BaseURL: server.URL}))
+
+	_, _, err = client.Get(context.Background(), "/", nil, nil)
+	assert.ErrorContains(err, "certificate")
+
+	_, err = httpmisc.LoadTLS(filepath.Join(t.TempDir(), "missing.pem"), "", "")
+	assert.Error(err)
+}
@@ -0,0 +1,158 @@
+// Copyright 2024 Raphael Thomazella. All rights reserved.
+// Use of this source code is governed by the BSD-3-Clause
+// license that can be found in the LICENSE file and online
+// at https://opensource.org/license/BSD-3-clause.
+
+package httpmisc
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/tcodes0/go/misc"
+)
+
+// reset values above this are unix times, below are seconds from now.
+const unixResetThreshold = 1_000_000_000
+
+// client side limits of requests sent, see SetClientOptions. Servers
+// reporting no requests left with X-RateLimit-Remaining pause requests
+// until X-RateLimit-Reset, in unix seconds or seconds from now.
+type RateLimit struct {
+	// requests per second, unlimited if 0 or less.
+	Rate float64
+	// requests sent at once after idling, 1 if 0 or less.
+	Burst int
+	// requests in flight, unlimited if 0 or less.
+	MaxInFlight int
+	// limits each host separately, including MaxInFlight.
+	PerHost bool
+}
+
+// limits requests of a client, shared by its copies.
+type limiter struct {
+	buckets map[string]*bucket
+	opts    RateLimit
+	mu      sync.Mutex
+}
+
+// token bucket of a host, or all hosts.
+type bucket struct {
+	last     time.Time
+	pausedTo time.Time     // set by rate limit headers
+	inFlight chan struct{} // nil if unlimited
+	tokens   float64
+}
+
+func newLimiter(opts *RateLimit) *limiter {
+	if opts == nil {
+		return nil
+	}
+
+	return &limiter{opts: *opts, buckets: map[string]*bucket{}}
+}
+
+// returns the bucket of host, must hold mu.
+func (l *limiter) bucket(host string) *bucket {
+	if !l.opts.PerHost {
+		host = ""
+	}
+
+	b, ok := l.buckets[host]
+	if !ok {
+		b = &bucket{tokens: float64(max(l.opts.Burst, 1)), last: time.Now()}
+		if l.opts.MaxInFlight > 0 {
+			b.inFlight = make(chan struct{}, l.opts.MaxInFlight)
+		}
+
+		l.buckets[host] = b
+	}
+
+	return b
+}
+
+// blocks until a request to host may be sent or ctx is done. Call release
+// once the response is read.
+func (l *limiter) wait(ctx context.Context, host string) (release func(), err error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	for {
+		delay, b := l.reserve(host)
+		if delay == 0 {
+			if b.inFlight == nil {
+				return func() {}, nil
+			}
+
+			select {
+			case b.inFlight <- struct{}{}:
+				return func() { <-b.inFlight }, nil
+			case <-ctx.Done():
+				return nil, misc.Wrap(ctx.Err(), "waiting for requests in flight")
+			}
+		}
+
+		err = wait(ctx, delay)
+		if err != nil {
+			return nil, misc.Wrap(err, "waiting for rate limit")
+		}
+	}
+}
+
+// takes a token from the bucket of host, or returns how long until one is
+// available.
+func (l *limiter) reserve(host string) (time.Duration, *bucket) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b := l.bucket(host)
+	now := time.Now()
+
+	if now.Before(b.pausedTo) {
+		return b.pausedTo.Sub(now), b
+	}
+
+	if l.opts.Rate <= 0 {
+		return 0, b
+	}
+
+	burst := float64(max(l.opts.Burst, 1))
+	b.tokens = min(b.tokens+now.Sub(b.last).Seconds()*l.opts.Rate, burst)
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+
+		return 0, b
+	}
+
+	return time.Duration((1 - b.tokens) / l.opts.Rate * float64(time.Second)), b
+}
+
+// pauses requests to host if header reports no requests left.
+func (l *limiter) observe(host string, header http.Header) {
+	if l == nil || header.Get("X-RateLimit-Remaining") != "0" {
+		return
+	}
+
+	reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil || reset <= 0 {
+		return
+	}
+
+	until := time.Now().Add(time.Duration(reset) * time.Second)
+	if reset > unixResetThreshold {
+		until = time.Unix(reset, 0)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if b := l.bucket(host); until.After(b.pausedTo) {
+		b.pausedTo = until
+	}
+}
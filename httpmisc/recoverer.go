@@ -12,13 +12,15 @@ import (
 	"github.com/tcodes0/go/logging"
 )
 
-// a middleware that recovers from panics.
+// a middleware that recovers from panics, logging them with the context
+// logger or, if there is none, a logger writing to log.Writer().
 func Recoverer(next http.Handler) http.Handler {
 	middlewareFunc := func(writer http.ResponseWriter, req *http.Request) {
 		//nolint:contextcheck // context in scope
 		defer func() {
 			if msg := recover(); msg != nil && msg != http.ErrAbortHandler {
-				logger := logging.FromContext(req.Context())
+				// a panic must not go unnoticed, fall back to the standard logger's writer
+				logger := logging.FromContextOr(req.Context(), logging.Create()).WithCtx(req.Context())
 
 				logger.ErrorData(map[string]any{
 					"recover":    msg,
@@ -0,0 +1,147 @@
+// Copyright 2024 Raphael Thomazella. All rights reserved.
+// Use of this source code is governed by the BSD-3-Clause
+// license that can be found in the LICENSE file and online
+// at https://opensource.org/license/BSD-3-clause.
+
+package httpmisc
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultBaseDelay = 200 * time.Millisecond
+	defaultMaxDelay  = 10 * time.Second
+)
+
+// retries of failed requests, see SetClientOptions. Requests with idempotent
+// methods are retried on transport errors and on status 429, 502, 503 and 504.
+type Retry struct {
+	// attempts including the first; 1 or less disables retries.
+	MaxAttempts int
+	// delay before the first retry, doubled every retry; 200ms by default.
+	// Delays are randomized between half and all of their value.
+	BaseDelay time.Duration
+	// longest delay between attempts, 10s by default. Longer Retry-After
+	// response headers are honored.
+	MaxDelay time.Duration
+}
+
+// fills defaults.
+func (r Retry) withDefaults() Retry {
+	if r.BaseDelay <= 0 {
+		r.BaseDelay = defaultBaseDelay
+	}
+
+	if r.MaxDelay <= 0 {
+		r.MaxDelay = defaultMaxDelay
+	}
+
+	return r
+}
+
+// returns how long to wait before retrying a request after attempt failed
+// with res or err, or false if it should not be retried.
+func (r Retry) delay(ctx context.Context, attempt int, method string, res *http.Response, err error) (time.Duration, bool) {
+	if attempt >= r.MaxAttempts || !idempotent(method) || ctx.Err() != nil {
+		return 0, false
+	}
+
+	if res == nil {
+		// errors building or authenticating the request repeat
+		transport := &transportError{}
+		if !errors.As(err, &transport) || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return 0, false
+		}
+	} else if !retryStatus(res.StatusCode) {
+		return 0, false
+	}
+
+	delay := min(r.BaseDelay<<(attempt-1), r.MaxDelay)
+	if delay <= 0 {
+		// shift overflow
+		delay = r.MaxDelay
+	}
+
+	delay = delay/2 + rand.N(delay/2+1)
+
+	if res != nil {
+		if after, ok := retryAfter(res.Header.Get("Retry-After")); ok {
+			delay = after
+		}
+	}
+
+	// waiting past the deadline only to fail is pointless
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+		return 0, false
+	}
+
+	return delay, true
+}
+
+// an error sending a request, requests failing with it may be retried.
+type transportError struct {
+	err error
+}
+
+func (e *transportError) Error() string {
+	return e.err.Error()
+}
+
+func (e *transportError) Unwrap() error {
+	return e.err
+}
+
+// blocks for delay or until ctx is done.
+func wait(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		//nolint:wrapcheck // caller wraps
+		return ctx.Err()
+	}
+}
+
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
+
+func retryStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
+
+// parses a Retry-After header, in seconds or an http date.
+func retryAfter(header string) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(header); err == nil {
+		return max(time.Until(date), 0), true
+	}
+
+	return 0, false
+}
@@ -14,8 +14,12 @@ import (
 
 // implements http.RoundTripper with debug logging.
 type Roundtrip struct {
-	Transport *http.Transport
+	// http.DefaultTransport if nil.
+	Transport http.RoundTripper
 	Logger    *logging.Logger
+	// secrets removed from logged headers and urls; nil uses
+	// logging.DefaultRedaction, an empty redaction logs everything.
+	Redaction *logging.Redaction
 	UserAgent string
 }
 
@@ -31,19 +35,28 @@ func (r Roundtrip) RoundTrip(req *http.Request) (*http.Response, error) {
 		r.Logger = &logging.Logger{}
 	}
 
-	r.Logger.DebugData(map[string]any{
+	if r.Redaction == nil {
+		r.Redaction = logging.DefaultRedaction()
+	}
+
+	logger := r.Logger.Named(LoggerName)
+
+	logger.DebugData(map[string]any{
 		"method":  req.Method,
-		"url":     req.URL.String(),
-		"headers": req.Header,
+		"url":     r.Redaction.String(req.URL.String()),
+		"headers": r.Redaction.Header(req.Header),
 	}, "req")
 
-	res, err := r.Transport.RoundTrip(req)
+	res, err := misc.Default(r.Transport, http.DefaultTransport).RoundTrip(req)
+	if err != nil {
+		return nil, misc.Wrap(err, "http roundtrip")
+	}
 
-	r.Logger.DebugData(map[string]any{
+	logger.DebugData(map[string]any{
 		"status":  res.Status,
 		"length":  res.ContentLength,
-		"headers": res.Header,
+		"headers": r.Redaction.Header(res.Header),
 	}, "res")
 
-	return res, misc.Wrap(err, "http roundtrip")
+	return res, nil
 }
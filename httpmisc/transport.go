@@ -0,0 +1,127 @@
+// Copyright 2024 Raphael Thomazella. All rights reserved.
+// Use of this source code is governed by the BSD-3-Clause
+// license that can be found in the LICENSE file and online
+// at https://opensource.org/license/BSD-3-clause.
+
+package httpmisc
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/tcodes0/go/misc"
+)
+
+const defaultKeepAlive = 30 * time.Second
+
+// options for the transport created by Client.Init, used when no transport
+// is given. Zero values keep the defaults of http.DefaultTransport.
+type TransportOptions struct {
+	// see LoadTLS.
+	TLS *tls.Config
+	// see http.ProxyURL; defaults to http.ProxyFromEnvironment.
+	Proxy func(*http.Request) (*url.URL, error)
+	// defaults to the client timeout.
+	DialTimeout           time.Duration
+	TLSHandshakeTimeout   time.Duration
+	ResponseHeaderTimeout time.Duration
+	IdleConnTimeout       time.Duration
+	MaxIdleConns          int
+	MaxIdleConnsPerHost   int
+	MaxConnsPerHost       int
+	// only HTTP/1.1 is used if set.
+	DisableHTTP2 bool
+}
+
+// creates a transport from options, dialing with dialTimeout if the
+// options have none.
+func (opts *TransportOptions) transport(dialTimeout time.Duration) *http.Transport {
+	opts = misc.Default(opts, &TransportOptions{})
+
+	//nolint:forcetypeassert // documented type
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	dialer := &net.Dialer{Timeout: misc.Default(opts.DialTimeout, dialTimeout), KeepAlive: defaultKeepAlive}
+	transport.DialContext = dialer.DialContext
+
+	if opts.TLS != nil {
+		transport.TLSClientConfig = opts.TLS.Clone()
+	}
+
+	if opts.Proxy != nil {
+		transport.Proxy = opts.Proxy
+	}
+
+	if opts.TLSHandshakeTimeout > 0 {
+		transport.TLSHandshakeTimeout = opts.TLSHandshakeTimeout
+	}
+
+	if opts.ResponseHeaderTimeout > 0 {
+		transport.ResponseHeaderTimeout = opts.ResponseHeaderTimeout
+	}
+
+	if opts.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = opts.IdleConnTimeout
+	}
+
+	if opts.MaxIdleConns > 0 {
+		transport.MaxIdleConns = opts.MaxIdleConns
+	}
+
+	if opts.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = opts.MaxIdleConnsPerHost
+	}
+
+	if opts.MaxConnsPerHost > 0 {
+		transport.MaxConnsPerHost = opts.MaxConnsPerHost
+	}
+
+	if opts.DisableHTTP2 {
+		transport.ForceAttemptHTTP2 = false
+		// a non-nil empty map disables HTTP/2
+		transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+
+	return transport
+}
+
+// creates a tls config trusting the pem CA certificates in caFile, in addition
+// to system ones, and presenting the pem client certificate in certFile and
+// keyFile. Empty paths are skipped.
+func LoadTLS(caFile, certFile, keyFile string) (*tls.Config, error) {
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, misc.Wrap(err, "reading ca")
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.New("no certificates in ca")
+		}
+
+		config.RootCAs = pool
+	}
+
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, misc.Wrap(err, "loading client certificate")
+		}
+
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
@@ -0,0 +1,190 @@
+// Copyright 2024 Raphael Thomazella. All rights reserved.
+// Use of this source code is governed by the BSD-3-Clause
+// license that can be found in the LICENSE file and online
+// at https://opensource.org/license/BSD-3-clause.
+
+package logging
+
+import (
+	"maps"
+	"sync"
+	"sync/atomic"
+)
+
+// queue size used if Async.Size is not set.
+const defaultAsyncSize = 1024
+
+// options for asynchronous logging, see OptAsync.
+type Async struct {
+	// lines queued before the full queue policy applies.
+	Size int
+	// drop lines when the queue is full instead of blocking the caller.
+	// Fatal lines are never dropped.
+	Drop bool
+}
+
+// option to output lines from a goroutine, so slow writers don't block
+// the caller. Use Logger.Flush to wait for queued lines to be written and
+// Logger.Close to stop the goroutine. Fatal methods flush before exit.
+func OptAsync(async Async) CreateOptions {
+	return func(c *createOpts) {
+		c.async = &async
+	}
+}
+
+// writes pending sampling summaries and waits for queued lines to be
+// written and passed to hooks.
+func (logger *Logger) Flush() {
+	if logger.core == nil {
+		return
+	}
+
+	if logger.core.sampler != nil {
+		logger.core.publishSummaries(logger.core.sampler.flush(logger.core.now()))
+	}
+
+	if logger.core.async != nil {
+		logger.core.async.flush()
+	}
+
+	if logger.core.hooks != nil {
+		logger.core.hooks.flush()
+	}
+}
+
+// writes pending sampling summaries, flushes and stops async output, later
+// lines are written synchronously. Hooks are stopped and receive no more
+// lines. Sinks are not closed.
+func (logger *Logger) Close() {
+	if logger.core == nil {
+		return
+	}
+
+	if logger.core.sampler != nil {
+		logger.core.publishSummaries(logger.core.sampler.flush(logger.core.now()))
+	}
+
+	if logger.core.async != nil {
+		logger.core.async.close()
+	}
+
+	if logger.core.hooks != nil {
+		logger.core.hooks.close()
+	}
+}
+
+// count of lines dropped because the async queue was full, see
+// Logger.HooksDropped for hooks.
+func (logger *Logger) Dropped() uint64 {
+	if logger.core == nil || logger.core.async == nil {
+		return 0
+	}
+
+	return logger.core.async.dropped.Load()
+}
+
+type asyncItem struct {
+	e       *entry
+	flushed chan struct{} // if set, closed once the item is reached
+}
+
+type asyncQueue struct {
+	queue   chan asyncItem
+	done    chan struct{}
+	dropped atomic.Uint64
+	mu      sync.RWMutex // guards sending on queue against closing it
+	closed  bool
+	drop    bool
+}
+
+func newAsyncQueue(opts Async, output func(e *entry)) *asyncQueue {
+	if opts.Size <= 0 {
+		opts.Size = defaultAsyncSize
+	}
+
+	q := &asyncQueue{
+		queue: make(chan asyncItem, opts.Size),
+		done:  make(chan struct{}),
+		drop:  opts.Drop,
+	}
+
+	go q.run(output)
+
+	return q
+}
+
+func (q *asyncQueue) run(output func(e *entry)) {
+	defer close(q.done)
+
+	for item := range q.queue {
+		if item.flushed != nil {
+			close(item.flushed)
+
+			continue
+		}
+
+		output(item.e)
+	}
+}
+
+// queues e, returns false if the queue is closed.
+func (q *asyncQueue) send(e *entry) bool {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	if q.closed {
+		return false
+	}
+
+	// the caller may change data after logging, and other queues share e
+	queued := *e
+	queued.data = maps.Clone(e.data)
+	e = &queued
+
+	if !q.drop || e.level >= LFatal {
+		q.queue <- asyncItem{e: e}
+
+		return true
+	}
+
+	select {
+	case q.queue <- asyncItem{e: e}:
+	default:
+		q.dropped.Add(1)
+	}
+
+	return true
+}
+
+func (q *asyncQueue) flush() {
+	flushed := make(chan struct{})
+
+	q.mu.RLock()
+
+	if q.closed {
+		q.mu.RUnlock()
+
+		return
+	}
+
+	q.queue <- asyncItem{flushed: flushed}
+	q.mu.RUnlock()
+
+	<-flushed
+}
+
+func (q *asyncQueue) close() {
+	q.mu.Lock()
+
+	if q.closed {
+		q.mu.Unlock()
+
+		return
+	}
+
+	q.closed = true
+	close(q.queue)
+	q.mu.Unlock()
+
+	<-q.done
+}
@@ -0,0 +1,198 @@
+// Copyright 2024 Raphael Thomazella. All rights reserved.
+// Use of this source code is governed by the BSD-3-Clause
+// license that can be found in the LICENSE file and online
+// at https://opensource.org/license/BSD-3-clause.
+
+package logging
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"unicode/utf8"
+
+	"github.com/tcodes0/go/hue"
+)
+
+const (
+	// time format used if Console.TimeFormat is not set.
+	defaultConsoleTime = "15:04:05.000"
+	// bytes of a value printed if Console.MaxValue is not set.
+	defaultConsoleMaxValue = 4096
+	// indentation of multi-line values.
+	consoleIndent = "    "
+	// width of the level column, fits "ERROR".
+	consoleLevelWidth = 5
+)
+
+// options for the console format, see OptConsole.
+type Console struct {
+	// a time.Format layout for the time column, "15:04:05.000" by default.
+	TimeFormat string
+	// values longer than this many bytes are cut and followed by "(+N bytes)",
+	// 4096 by default; negative prints whole values.
+	MaxValue int
+}
+
+// option to print lines for people reading a terminal: time, level and
+// caller are aligned in columns, multi-line data values and messages are
+// indented on the lines below and huge values are cut. Colors are enabled if
+// OptColor is used or the writer is a terminal, unless NO_COLOR is set.
+// Sinks use the console format with Format set to FormatConsole.
+func OptConsole(console Console) CreateOptions {
+	return func(c *createOpts) {
+		c.format = FormatConsole
+		c.console = &console
+	}
+}
+
+type consoleEncoder struct {
+	opts Console
+	// width of the widest caller printed, the caller column grows to it
+	callerWidth atomic.Int64
+}
+
+func newConsoleEncoder(opts *Console) *consoleEncoder {
+	enc := &consoleEncoder{}
+	if opts != nil {
+		enc.opts = *opts
+	}
+
+	if enc.opts.TimeFormat == "" {
+		enc.opts.TimeFormat = defaultConsoleTime
+	}
+
+	if enc.opts.MaxValue == 0 {
+		enc.opts.MaxValue = defaultConsoleMaxValue
+	}
+
+	return enc
+}
+
+// encodes the entry as a line with aligned columns and inline data,
+// followed by indented lines for multi-line data values.
+func (enc *consoleEncoder) encode(e *entry, color bool) string {
+	var buf, below strings.Builder
+
+	caller := e.caller()
+	width := int(enc.callerWidth.Load())
+
+	for len(caller) > width && !enc.callerWidth.CompareAndSwap(int64(width), int64(len(caller))) {
+		width = int(enc.callerWidth.Load())
+	}
+
+	width = max(width, len(caller))
+	level := strings.ToUpper(e.level.String())
+	level += strings.Repeat(" ", max(consoleLevelWidth-len(level), 0))
+	caller += strings.Repeat(" ", width-len(caller))
+
+	buf.WriteString(paint(color, hue.Gray, e.time.Format(enc.opts.TimeFormat)))
+	buf.WriteByte(' ')
+	buf.WriteString(paint(color, levelColor(e.level), level))
+	buf.WriteByte(' ')
+	buf.WriteString(paint(color, hue.Gray, caller))
+	buf.WriteByte(' ')
+
+	if e.name != "" {
+		buf.WriteString(paint(color, hue.Gray, e.name+":"))
+		buf.WriteByte(' ')
+	}
+
+	message, rest, multiline := strings.Cut(e.message, "\n")
+	buf.WriteString(message)
+
+	if multiline {
+		writeIndented(&below, rest)
+	}
+
+	for _, key := range sortedKeys(e.data) {
+		val := enc.cut(consoleValue(e.data[key]))
+
+		if strings.Contains(val, "\n") {
+			below.WriteString("\n" + consoleIndent + paint(color, hue.Brown, quote(key)) + equals)
+			writeIndented(&below, val)
+
+			continue
+		}
+
+		buf.WriteByte(' ')
+		buf.WriteString(paint(color, hue.Brown, quote(key)) + equals + val)
+	}
+
+	return strings.TrimRight(buf.String(), " ") + below.String()
+}
+
+// cuts s to the max value size, followed by the count of bytes cut.
+func (enc *consoleEncoder) cut(s string) string {
+	limit := enc.opts.MaxValue
+	if limit < 0 || len(s) <= limit {
+		return s
+	}
+
+	// don't split a multi-byte character
+	for limit > 0 && !utf8.RuneStart(s[limit]) {
+		limit--
+	}
+
+	return s[:limit] + " (+" + strconv.Itoa(len(s)-limit) + " bytes)"
+}
+
+// strings are printed as is if multi-line, quoted if needed otherwise;
+// other values are formatted like text data.
+func consoleValue(val any) string {
+	if nilPointer(val) {
+		return formatValue(val)
+	}
+
+	var s string
+
+	switch tVal := val.(type) {
+	default:
+		return formatValue(val)
+	case string:
+		s = tVal
+	case []byte:
+		s = string(tVal)
+	case error:
+		s = tVal.Error()
+	}
+
+	if strings.Contains(strings.TrimRight(s, "\n"), "\n") {
+		return strings.TrimRight(s, "\n")
+	}
+
+	return quote(s)
+}
+
+// writes each line of s on a new indented line.
+func writeIndented(buf *strings.Builder, s string) {
+	for _, line := range strings.Split(s, "\n") {
+		buf.WriteString("\n" + consoleIndent + consoleIndent + line)
+	}
+}
+
+func paint(color bool, c int, s string) string {
+	if !color {
+		return s
+	}
+
+	return hue.Printc(c, s, hue.End)
+}
+
+// reports if w is a terminal and NO_COLOR is not set.
+func isTerminal(w io.Writer) bool {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+
+	file, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+
+	stat, err := file.Stat()
+
+	return err == nil && stat.Mode()&os.ModeCharDevice != 0
+}
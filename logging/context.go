@@ -0,0 +1,121 @@
+// Copyright 2024 Raphael Thomazella. All rights reserved.
+// Use of this source code is governed by the BSD-3-Clause
+// license that can be found in the LICENSE file and online
+// at https://opensource.org/license/BSD-3-clause.
+
+package logging
+
+import (
+	"context"
+)
+
+// data keys of values read from context, see ContextFields.
+const (
+	FieldRequestID = "requestId"
+	FieldTraceID   = "traceId"
+	FieldSpanID    = "spanId"
+)
+
+type requestIDKey struct{}
+
+type traceKey struct{}
+
+type trace struct {
+	traceID string
+	spanID  string
+}
+
+// returns a new context with a request id, like one made by an
+// identifier.Generator, added to lines logged with the *Ctx methods.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// retrieves a request id from a context, see WithRequestID.
+func RequestID(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey{}).(string)
+
+	return id, ok && id != ""
+}
+
+// returns a new context with trace and span ids, added to lines logged with
+// the *Ctx methods. Empty ids are ignored.
+func WithTrace(ctx context.Context, traceID, spanID string) context.Context {
+	return context.WithValue(ctx, traceKey{}, trace{traceID: traceID, spanID: spanID})
+}
+
+// retrieves a logger from a context, or fallback if there is none, see
+// Logger.WithContext. Libraries can use a zero Logger as fallback to not log.
+func FromContextOr(ctx context.Context, fallback *Logger) *Logger {
+	if logger, ok := ctx.Value(contextKey).(*Logger); ok {
+		return logger
+	}
+
+	return fallback
+}
+
+// returns data with the request id, see WithRequestID, and the
+// trace and span ids, see WithTrace, found in ctx; nil if none are found.
+func ContextFields(ctx context.Context) map[string]any {
+	var fields map[string]any
+
+	add := func(key, val string) {
+		if val == "" {
+			return
+		}
+
+		if fields == nil {
+			fields = map[string]any{}
+		}
+
+		fields[key] = val
+	}
+
+	if id, ok := RequestID(ctx); ok {
+		add(FieldRequestID, id)
+	}
+
+	if t, ok := ctx.Value(traceKey{}).(trace); ok {
+		add(FieldTraceID, t.traceID)
+		add(FieldSpanID, t.spanID)
+	}
+
+	return fields
+}
+
+// sends a message with level info and data from ctx, see ContextFields.
+func (logger *Logger) InfoCtx(ctx context.Context, msg ...any) {
+	logger.out(LInfo, ContextFields(ctx), msg...)
+}
+
+// sends a message with level warn and data from ctx, see ContextFields.
+func (logger *Logger) WarnCtx(ctx context.Context, msg ...any) {
+	logger.out(LWarn, ContextFields(ctx), msg...)
+}
+
+// sends a message with level error and data from ctx, see ContextFields.
+func (logger *Logger) ErrorCtx(ctx context.Context, msg ...any) {
+	logger.out(LError, ContextFields(ctx), msg...)
+}
+
+// sends a message with level debug and data from ctx, see ContextFields.
+func (logger *Logger) DebugCtx(ctx context.Context, msg ...any) {
+	logger.out(LDebug, ContextFields(ctx), msg...)
+}
+
+// sends a message with level fatal and data from ctx, see ContextFields;
+// calls the logger exit function.
+func (logger *Logger) FatalCtx(ctx context.Context, msg ...any) {
+	logger.out(LFatal, ContextFields(ctx), msg...)
+	logger.exit()
+}
+
+// returns a logger that adds data from ctx to every line, see ContextFields and With.
+func (logger *Logger) WithCtx(ctx context.Context) *Logger {
+	fields := ContextFields(ctx)
+	if fields == nil {
+		return logger
+	}
+
+	return logger.With(fields)
+}
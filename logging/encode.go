@@ -0,0 +1,237 @@
+// Copyright 2024 Raphael Thomazella. All rights reserved.
+// Use of this source code is governed by the BSD-3-Clause
+// license that can be found in the LICENSE file and online
+// at https://opensource.org/license/BSD-3-clause.
+
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tcodes0/go/hue"
+)
+
+// a log line before encoding.
+type entry struct {
+	time    time.Time
+	data    map[string]any
+	file    string
+	message string
+	name    string // of the logger, see Logger.Named
+	line    int
+	pc      uintptr
+	level   Level
+}
+
+// creates an entry resolving file and line from pc.
+func newEntry(t time.Time, level Level, pc uintptr, msg string, data map[string]any) *entry {
+	e := &entry{
+		time:    t,
+		level:   level,
+		pc:      pc,
+		message: msg,
+		data:    data,
+		file:    "???",
+	}
+
+	if pc != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+		if frame.File != "" {
+			e.file, e.line = frame.File, frame.Line
+		}
+	}
+
+	return e
+}
+
+// file:line of the log call, file is the base name.
+func (e *entry) caller() string {
+	return filepath.Base(e.file) + ":" + strconv.Itoa(e.line)
+}
+
+// encodes the entry in the log package text format, using flags for the header.
+func encodeText(e *entry, flags int, color bool) string {
+	prefix := levelPrefix(e.level, color)
+	data := formatData(e.data, color)
+	buf := make([]byte, 0, len(prefix)+len(e.message)+len(data)+64)
+
+	if flags&log.Lmsgprefix == 0 {
+		buf = append(buf, prefix...)
+	}
+
+	buf = appendHeader(buf, e, flags)
+
+	if flags&log.Lmsgprefix != 0 {
+		buf = append(buf, prefix...)
+	}
+
+	if color {
+		// end color of the log line information, started on prefix
+		buf = append(buf, hue.End...)
+	}
+
+	buf = append(buf, data...)
+	buf = append(buf, e.message...)
+
+	return string(buf)
+}
+
+// formats data as text, sorted by key.
+func formatData(data map[string]any, color bool) string {
+	if len(data) == 0 {
+		return ""
+	}
+
+	dataMsg := ""
+
+	for _, key := range sortedKeys(data) {
+		sVal := formatValue(data[key])
+		key = quote(key)
+
+		if color {
+			dataMsg += hue.Printc(hue.Brown, key) + hue.Printc(hue.Gray, equals) +
+				hue.Printc(hue.Brown, sVal) + hue.Printc(hue.Gray, comma)
+		} else {
+			dataMsg += key + equals + sVal + comma
+		}
+	}
+
+	if color {
+		return strings.TrimSuffix(dataMsg, comma) + hue.End + " "
+	}
+
+	return "(" + strings.TrimSuffix(dataMsg, comma) + ")" + " "
+}
+
+// mirrors log.Logger's header formatting.
+func appendHeader(buf []byte, e *entry, flags int) []byte {
+	if flags&(log.Ldate|log.Ltime|log.Lmicroseconds) != 0 {
+		t := e.time
+		if flags&log.LUTC != 0 {
+			t = t.UTC()
+		}
+
+		if flags&log.Ldate != 0 {
+			buf = t.AppendFormat(buf, "2006/01/02 ")
+		}
+
+		if flags&log.Lmicroseconds != 0 {
+			buf = t.AppendFormat(buf, "15:04:05.000000 ")
+		} else if flags&log.Ltime != 0 {
+			buf = t.AppendFormat(buf, "15:04:05 ")
+		}
+	}
+
+	if flags&(log.Lshortfile|log.Llongfile) != 0 {
+		file := e.file
+		if flags&log.Lshortfile != 0 {
+			file = filepath.Base(file)
+		}
+
+		buf = append(buf, file...)
+		buf = append(buf, ':')
+		buf = strconv.AppendInt(buf, int64(e.line), 10)
+		buf = append(buf, ": "...)
+	}
+
+	return buf
+}
+
+func levelPrefix(level Level, color bool) string {
+	//nolint:exhaustive // default handles LInfo
+	switch level {
+	default:
+		if color {
+			return infoColor
+		}
+
+		return info
+	case LWarn:
+		if color {
+			return warnColor
+		}
+
+		return warn
+	case LError:
+		if color {
+			return erroColor
+		}
+
+		return erro
+	case LFatal:
+		if color {
+			return fatalColor
+		}
+
+		return fatal
+	case LDebug:
+		if color {
+			return debugColor
+		}
+
+		return debug
+	}
+}
+
+type jsonEntry struct {
+	Data    map[string]any `json:"data,omitempty"`
+	Time    string         `json:"time"`
+	Level   string         `json:"level"`
+	Caller  string         `json:"caller"`
+	Logger  string         `json:"logger,omitempty"`
+	Message string         `json:"msg"`
+}
+
+// encodes the entry as a json object, data values keep their types
+// unless they can't be marshalled.
+func encodeJSON(e *entry) string {
+	line := jsonEntry{
+		Time:    e.time.UTC().Format(time.RFC3339Nano),
+		Level:   e.level.String(),
+		Caller:  e.caller(),
+		Logger:  e.name,
+		Message: e.message,
+	}
+
+	if len(e.data) != 0 {
+		line.Data = make(map[string]any, len(e.data))
+
+		for key, val := range e.data {
+			line.Data[key] = jsonValue(val)
+		}
+	}
+
+	out, err := json.Marshal(line)
+	if err != nil {
+		// unreachable, data values are checked by jsonValue
+		return fmt.Sprintf(`{"level":%q,"msg":%q}`, LError.String(), "marshalling log line: "+err.Error())
+	}
+
+	return string(out)
+}
+
+func jsonValue(val any) any {
+	if nilPointer(val) {
+		return nil
+	}
+
+	switch tVal := val.(type) {
+	case error:
+		return tVal.Error()
+	case []byte:
+		return string(tVal)
+	}
+
+	if _, err := json.Marshal(val); err != nil {
+		return fmt.Sprintf("%v", val)
+	}
+
+	return val
+}
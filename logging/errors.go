@@ -0,0 +1,243 @@
+// Copyright 2024 Raphael Thomazella. All rights reserved.
+// Use of this source code is governed by the BSD-3-Clause
+// license that can be found in the LICENSE file and online
+// at https://opensource.org/license/BSD-3-clause.
+
+package logging
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const errorKey = "error"
+
+// matches file:line prefixes added by misc.Wrapfl.
+var reLocation = regexp.MustCompile(`^(?:[\w.-]+\.go:\d+|\?:\?)$`)
+
+// an error with data to log along with it, see AttachData.
+type DataError interface {
+	error
+	LogData() map[string]any
+}
+
+// an error with a stack trace to log along with it, see AttachStack.
+type StackError interface {
+	error
+	Stack() []byte
+}
+
+type dataError struct {
+	err  error
+	data map[string]any
+}
+
+var _ DataError = (*dataError)(nil)
+
+func (e *dataError) Error() string {
+	return e.err.Error()
+}
+
+func (e *dataError) Unwrap() error {
+	return e.err
+}
+
+func (e *dataError) LogData() map[string]any {
+	return e.data
+}
+
+type stackError struct {
+	err   error
+	stack []byte
+}
+
+var _ StackError = (*stackError)(nil)
+
+func (e *stackError) Error() string {
+	return e.err.Error()
+}
+
+func (e *stackError) Unwrap() error {
+	return e.err
+}
+
+func (e *stackError) Stack() []byte {
+	return e.stack
+}
+
+// wraps err with data that Logger.Err adds to the line; the message is unchanged.
+func AttachData(err error, data map[string]any) error {
+	if err == nil {
+		return nil
+	}
+
+	return &dataError{err: err, data: data}
+}
+
+// wraps err with the current goroutine's stack, that Logger.Err adds to the
+// line; the message is unchanged.
+func AttachStack(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &stackError{err: err, stack: captureStack(false)}
+}
+
+// sends a message with level error and data describing err, see ErrorFields.
+func (logger *Logger) Err(err error, msg ...any) {
+	logger.out(LError, ErrorFields(err), msg...)
+}
+
+// sends a message with level warn and data describing err, see ErrorFields.
+func (logger *Logger) WarnErr(err error, msg ...any) {
+	logger.out(LWarn, ErrorFields(err), msg...)
+}
+
+// sends a message with level fatal and data describing err, see ErrorFields;
+// calls the logger exit function.
+func (logger *Logger) FatalErr(err error, msg ...any) {
+	logger.out(LFatal, ErrorFields(err), msg...)
+	logger.exit()
+}
+
+// describes err as log data. "error" is the message without misc.Wrapfl
+// file:line prefixes. If err wraps other errors, each layer is a field:
+// "error.0" is the outermost, the location added by Wrapfl follows the
+// layer message in parenthesis. Errors joined with errors.Join branch into
+// "error.N.0", "error.N.1" and so on. Data and stacks attached to any error
+// in the tree are added, "error.stack" holds the innermost stack.
+func ErrorFields(err error) map[string]any {
+	if err == nil {
+		return nil
+	}
+
+	fields := map[string]any{errorKey: cleanMessage(err.Error())}
+	layers := errorLayers(fields, err)
+
+	if len(layers) > 1 || (len(layers) == 1 && layers[0].msg != fields[errorKey]) {
+		addLayers(fields, errorKey, layers)
+	}
+
+	return fields
+}
+
+// a layer of an error chain, either a message or joined branches.
+type errorLayer struct {
+	msg      string
+	branches [][]errorLayer
+}
+
+// walks the chain of err, collecting attached data and stacks into fields.
+func errorLayers(fields map[string]any, err error) []errorLayer {
+	var layers []errorLayer
+
+	location := ""
+
+	for err != nil {
+		collectAttached(fields, err)
+
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			children := joined.Unwrap()
+			layer := errorLayer{}
+			texts := make([]string, 0, len(children))
+
+			for _, child := range children {
+				layer.branches = append(layer.branches, errorLayers(fields, child))
+				texts = append(texts, child.Error())
+			}
+
+			if err.Error() != strings.Join(texts, "\n") {
+				layers = append(layers, errorLayer{msg: withLocation(cleanMessage(err.Error()), location)})
+			}
+
+			return append(layers, layer)
+		}
+
+		inner := errors.Unwrap(err)
+		msg := err.Error()
+
+		if inner != nil && strings.HasSuffix(msg, inner.Error()) {
+			msg = strings.TrimSuffix(strings.TrimSuffix(msg, inner.Error()), ": ")
+		}
+
+		switch {
+		case msg == "":
+		case reLocation.MatchString(msg):
+			location = msg
+		default:
+			layers = append(layers, errorLayer{msg: withLocation(msg, location)})
+			location = ""
+		}
+
+		err = inner
+	}
+
+	return layers
+}
+
+func addLayers(fields map[string]any, key string, layers []errorLayer) {
+	for i, layer := range layers {
+		layerKey := key + "." + strconv.Itoa(i)
+
+		if layer.branches == nil {
+			fields[layerKey] = layer.msg
+
+			continue
+		}
+
+		for j, branch := range layer.branches {
+			branchKey := layerKey + "." + strconv.Itoa(j)
+
+			if len(branch) == 1 && branch[0].branches == nil {
+				fields[branchKey] = branch[0].msg
+			} else {
+				addLayers(fields, branchKey, branch)
+			}
+		}
+	}
+}
+
+func collectAttached(fields map[string]any, err error) {
+	//nolint:errorlint // checking this error only, not the chain
+	switch tErr := err.(type) {
+	case DataError:
+		for key, val := range tErr.LogData() {
+			if _, ok := fields[key]; !ok {
+				fields[key] = val
+			}
+		}
+	case StackError:
+		fields[errorKey+".stack"] = tErr.Stack()
+	}
+}
+
+func withLocation(msg, location string) string {
+	if location == "" {
+		return msg
+	}
+
+	return msg + " (" + location + ")"
+}
+
+// removes misc.Wrapfl file:line prefixes from an error message.
+func cleanMessage(msg string) string {
+	lines := strings.Split(msg, "\n")
+
+	for i, line := range lines {
+		parts := strings.Split(line, ": ")
+		kept := make([]string, 0, len(parts))
+
+		for _, part := range parts {
+			if !reLocation.MatchString(part) {
+				kept = append(kept, part)
+			}
+		}
+
+		lines[i] = strings.Join(kept, ": ")
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,134 @@
+// Copyright 2024 Raphael Thomazella. All rights reserved.
+// Use of this source code is governed by the BSD-3-Clause
+// license that can be found in the LICENSE file and online
+// at https://opensource.org/license/BSD-3-clause.
+
+package logging
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// nested maps, slices and structs deeper than this are printed with fmt.
+const maxFormatDepth = 5
+
+// returns the keys of data, sorted.
+func sortedKeys[V any](data map[string]V) []string {
+	return slices.Sorted(maps.Keys(data))
+}
+
+// reports whether val is a typed nil pointer, whose String or Error methods
+// may panic.
+func nilPointer(val any) bool {
+	ref := reflect.ValueOf(val)
+
+	return ref.Kind() == reflect.Pointer && ref.IsNil()
+}
+
+// formats a data value for text output. Strings are quoted if needed,
+// maps and structs are printed as {k=v k2=v2} sorted by key and slices as [v v2].
+func formatValue(val any) string {
+	return formatAny(val, 0)
+}
+
+func formatAny(val any, depth int) string {
+	if nilPointer(val) {
+		return "<nil>"
+	}
+
+	switch tVal := val.(type) {
+	case nil:
+		return "<nil>"
+	case string:
+		return quote(tVal)
+	case []byte:
+		return quote(string(tVal))
+	case error:
+		return quote(tVal.Error())
+	case time.Time:
+		return tVal.Format(time.RFC3339Nano)
+	case time.Duration:
+		return tVal.String()
+	case fmt.Stringer:
+		return quote(tVal.String())
+	}
+
+	if depth >= maxFormatDepth {
+		return quote(fmt.Sprintf("%v", val))
+	}
+
+	ref := reflect.ValueOf(val)
+
+	//nolint:exhaustive // default handles scalars
+	switch ref.Kind() {
+	default:
+		return quote(fmt.Sprintf("%v", val))
+	case reflect.Pointer, reflect.Interface:
+		if ref.IsNil() {
+			return "<nil>"
+		}
+
+		return formatAny(ref.Elem().Interface(), depth+1)
+	case reflect.Map:
+		pairs := make([]string, 0, ref.Len())
+		iter := ref.MapRange()
+
+		for iter.Next() {
+			pairs = append(pairs, formatPair(fmt.Sprint(iter.Key().Interface()), iter.Value().Interface(), depth))
+		}
+
+		slices.Sort(pairs)
+
+		return "{" + strings.Join(pairs, " ") + "}"
+	case reflect.Slice, reflect.Array:
+		items := make([]string, 0, ref.Len())
+
+		for i := range ref.Len() {
+			items = append(items, formatAny(ref.Index(i).Interface(), depth+1))
+		}
+
+		return "[" + strings.Join(items, " ") + "]"
+	case reflect.Struct:
+		pairs := make([]string, 0, ref.NumField())
+
+		for i := range ref.NumField() {
+			field := ref.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+
+			pairs = append(pairs, formatPair(field.Name, ref.Field(i).Interface(), depth))
+		}
+
+		return "{" + strings.Join(pairs, " ") + "}"
+	}
+}
+
+func formatPair(key string, val any, depth int) string {
+	return quote(key) + equals + formatAny(val, depth+1)
+}
+
+// quotes s if it's empty or contains characters that would make the
+// output ambiguous, like spaces, separators or control characters.
+func quote(s string) string {
+	if s == "" {
+		return `""`
+	}
+
+	if strings.IndexFunc(s, needsQuote) == -1 {
+		return s
+	}
+
+	return strconv.Quote(s)
+}
+
+func needsQuote(r rune) bool {
+	return unicode.IsSpace(r) || !unicode.IsPrint(r) || strings.ContainsRune(`,="(){}[]`, r)
+}
@@ -0,0 +1,156 @@
+// Copyright 2024 Raphael Thomazella. All rights reserved.
+// Use of this source code is governed by the BSD-3-Clause
+// license that can be found in the LICENSE file and online
+// at https://opensource.org/license/BSD-3-clause.
+
+package logging
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// a log line passed to hooks.
+type Entry struct {
+	Time time.Time
+	// line data, including logger fields, redacted if redaction is enabled.
+	Fields  map[string]any
+	Message string
+	// file:line of the log call, file is the base name.
+	Caller string
+	// of the logger, see Logger.Named.
+	Name  string
+	Level Level
+}
+
+// receives lines that pass the level filter, including lines suppressed by
+// sampling, see OptHook. Sampling summaries are not passed to hooks.
+type Hook interface {
+	// called from a goroutine, one line at a time, in logging order.
+	// Fields must not be modified.
+	Fire(e Entry)
+}
+
+// option to pass lines to hook, can be used more than once. Hooks are called
+// from a goroutine so they don't block the caller; lines are dropped if
+// hooks fall behind, except fatal lines, see Logger.HooksDropped.
+// Logger.Flush waits for hooks, and fatal methods flush before exit.
+func OptHook(hook Hook) CreateOptions {
+	return func(c *createOpts) {
+		c.hooks = append(c.hooks, hook)
+	}
+}
+
+// option to pass every line to hook by the logging goroutine, before the
+// line is written. The hook must return quickly and be safe for concurrent use.
+func OptSyncHook(hook Hook) CreateOptions {
+	return func(c *createOpts) {
+		c.syncHooks = append(c.syncHooks, hook)
+	}
+}
+
+// count of lines not passed to hooks because they fell behind, see OptHook.
+func (logger *Logger) HooksDropped() uint64 {
+	if logger.core == nil || logger.core.hooks == nil {
+		return 0
+	}
+
+	return logger.core.hooks.dropped.Load()
+}
+
+func newHookQueue(hooks []Hook) *asyncQueue {
+	return newAsyncQueue(Async{Drop: true}, func(e *entry) {
+		fire(hooks, e)
+	})
+}
+
+// passes e to hooks.
+func fire(hooks []Hook, e *entry) {
+	hookEntry := Entry{
+		Time:    e.time,
+		Fields:  e.data,
+		Message: e.message,
+		Caller:  e.caller(),
+		Name:    e.name,
+		Level:   e.level,
+	}
+
+	for _, hook := range hooks {
+		hook.Fire(hookEntry)
+	}
+}
+
+// a hook counting lines per level, and per level and logger name; the zero
+// value is ready to use. Register it with OptSyncHook to count every line,
+// OptHook drops lines when hooks fall behind.
+type CountHook struct {
+	names  map[string]*[LNone + 1]uint64
+	totals [LNone + 1]atomic.Uint64
+	mu     sync.Mutex
+}
+
+var _ Hook = (*CountHook)(nil)
+
+// implementation of Hook.
+func (h *CountHook) Fire(e Entry) {
+	if e.Level > LNone {
+		return
+	}
+
+	h.totals[e.Level].Add(1)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.names == nil {
+		h.names = map[string]*[LNone + 1]uint64{}
+	}
+
+	counts := h.names[e.Name]
+	if counts == nil {
+		counts = &[LNone + 1]uint64{}
+		h.names[e.Name] = counts
+	}
+
+	counts[e.Level]++
+}
+
+// count of lines with level.
+func (h *CountHook) Total(level Level) uint64 {
+	if level > LNone {
+		return 0
+	}
+
+	return h.totals[level].Load()
+}
+
+// count of lines per level, levels without lines are omitted.
+func (h *CountHook) Totals() map[Level]uint64 {
+	totals := map[Level]uint64{}
+
+	for level := LDebug; level <= LNone; level++ {
+		if n := h.totals[level].Load(); n > 0 {
+			totals[level] = n
+		}
+	}
+
+	return totals
+}
+
+// count of lines with level logged by loggers named name, see Logger.Named.
+// Lines of child loggers, like "name.child", are not included.
+func (h *CountHook) NameTotal(name string, level Level) uint64 {
+	if level > LNone {
+		return 0
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if counts := h.names[name]; counts != nil {
+		return counts[level]
+	}
+
+	return 0
+}
@@ -0,0 +1,95 @@
+// Copyright 2024 Raphael Thomazella. All rights reserved.
+// Use of this source code is governed by the BSD-3-Clause
+// license that can be found in the LICENSE file and online
+// at https://opensource.org/license/BSD-3-clause.
+
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// request bodies larger than this are rejected by LevelHandler.
+const maxLevelBody = 1 << 16
+
+// body of LevelHandler requests and responses.
+type levelsJSON struct {
+	// levels of named loggers, see Logger.Named.
+	Names map[string]string `json:"names"`
+	// default level.
+	Level string `json:"level"`
+}
+
+// returns an http.Handler to read and replace the levels of logger and the
+// loggers sharing its writer. GET responds with the levels as json, like
+// {"level":"info","names":{"httpmisc":"debug"}}; PUT sets them from a body
+// of the same shape, see Logger.SetLevelSpec. Levels are parsed by ParseLevel.
+func LevelHandler(logger *Logger) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+		switch req.Method {
+		case http.MethodGet:
+		case http.MethodPut:
+			levels, err := decodeLevels(http.MaxBytesReader(writer, req.Body, maxLevelBody))
+			if err != nil {
+				http.Error(writer, err.Error(), http.StatusBadRequest)
+
+				return
+			}
+
+			logger.SetLevelSpec(levels)
+			logger.WarnData(map[string]any{"levels": levels.String()}, "levels changed")
+		default:
+			writer.Header().Set("Allow", strings.Join([]string{http.MethodGet, http.MethodPut}, ", "))
+			http.Error(writer, "method not allowed", http.StatusMethodNotAllowed)
+
+			return
+		}
+
+		writer.Header().Set("Content-Type", "application/json")
+		//nolint:errchkjson // nothing to do if the client is gone
+		_ = json.NewEncoder(writer).Encode(encodeLevels(logger.LevelSpec()))
+	})
+}
+
+func encodeLevels(levels *LevelSpec) levelsJSON {
+	body := levelsJSON{Level: levels.Default.String(), Names: make(map[string]string, len(levels.Names))}
+
+	for name, level := range levels.Names {
+		body.Names[name] = level.String()
+	}
+
+	return body
+}
+
+func decodeLevels(r io.Reader) (*LevelSpec, error) {
+	body := levelsJSON{}
+
+	err := json.NewDecoder(r).Decode(&body)
+	if err != nil {
+		return nil, fmt.Errorf("decoding levels: %w", err)
+	}
+
+	level, err := ParseLevel(body.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	levels := &LevelSpec{Default: level, Names: make(map[string]Level, len(body.Names))}
+
+	for name, rawLevel := range body.Names {
+		if name == "" {
+			return nil, fmt.Errorf("%w: empty name", ErrInvalidLevel)
+		}
+
+		levels.Names[name], err = ParseLevel(rawLevel)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return levels, nil
+}
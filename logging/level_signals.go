@@ -0,0 +1,64 @@
+// Copyright 2024 Raphael Thomazella. All rights reserved.
+// Use of this source code is governed by the BSD-3-Clause
+// license that can be found in the LICENSE file and online
+// at https://opensource.org/license/BSD-3-clause.
+
+//go:build !windows
+
+package logging
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// blocks changing the levels of logger on signals until ctx is done.
+// SIGUSR1 lowers all levels by one, logging more; SIGUSR2 raises them by one.
+// Levels set before the first signal are restored once timeout passes
+// without signals, and when ctx is done.
+func (logger *Logger) RoutineLevelSignals(ctx context.Context, timeout time.Duration) {
+	signalChan := make(chan os.Signal, 1)
+	signal.Notify(signalChan, syscall.SIGUSR1, syscall.SIGUSR2)
+
+	defer signal.Stop(signalChan)
+
+	var original *LevelSpec
+
+	restore := time.NewTimer(timeout)
+	restore.Stop()
+
+	defer restore.Stop()
+
+	for {
+		select {
+		case sig := <-signalChan:
+			if original == nil {
+				original = logger.LevelSpec()
+			}
+
+			step := 1
+			if sig == syscall.SIGUSR1 {
+				step = -1
+			}
+
+			levels := logger.LevelSpec().shift(step)
+			logger.SetLevelSpec(levels)
+			logger.WarnData(map[string]any{"levels": levels.String(), "restore": timeout}, "levels changed")
+			restore.Reset(timeout)
+		case <-restore.C:
+			logger.SetLevelSpec(original)
+			logger.WarnData(map[string]any{"levels": original.String()}, "levels restored")
+
+			original = nil
+		case <-ctx.Done():
+			if original != nil {
+				logger.SetLevelSpec(original)
+			}
+
+			return
+		}
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2024 Raphael Thomazella. All rights reserved.
+// Use of this source code is governed by the BSD-3-Clause
+// license that can be found in the LICENSE file and online
+// at https://opensource.org/license/BSD-3-clause.
+
+package logging
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidLevel = errors.New("invalid level")
+
+// parses a level name, case insensitive, or number. "warning" is accepted as LWarn.
+func ParseLevel(name string) (Level, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	if n, err := strconv.Atoi(name); err == nil {
+		if n < int(LDebug) || n > int(LNone) {
+			return 0, fmt.Errorf("%w: %s", ErrInvalidLevel, name)
+		}
+
+		return Level(n), nil
+	}
+
+	if name == "warning" {
+		return LWarn, nil
+	}
+
+	for level := LDebug; level <= LNone; level++ {
+		if level.String() == name {
+			return level, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: %s", ErrInvalidLevel, name)
+}
+
+// a default level and levels for named loggers, see Logger.Named.
+type LevelSpec struct {
+	// level of named loggers with a name, parent or trailing name in this map.
+	Names map[string]Level
+	// level of other loggers.
+	Default Level
+}
+
+// parses a comma separated list of levels, optionally prefixed by a logger name
+// and an equals sign, like "info,httpmisc=debug,t0runner=warn".
+// An unnamed level sets the default level, which is LInfo if omitted.
+func ParseLevelSpec(spec string) (*LevelSpec, error) {
+	levels := &LevelSpec{Default: LInfo, Names: map[string]Level{}}
+	hasDefault := false
+
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		name, rawLevel, named := strings.Cut(part, "=")
+		if !named {
+			rawLevel = name
+		}
+
+		level, err := ParseLevel(rawLevel)
+		if err != nil {
+			return nil, fmt.Errorf("parsing level spec %q: %w", spec, err)
+		}
+
+		name = strings.TrimSpace(name)
+
+		switch {
+		case named && name == "":
+			return nil, fmt.Errorf("parsing level spec %q: empty name", spec)
+		case named:
+			levels.Names[name] = level
+		case hasDefault:
+			return nil, fmt.Errorf("parsing level spec %q: more than one default level", spec)
+		default:
+			hasDefault = true
+			levels.Default = level
+		}
+	}
+
+	return levels, nil
+}
+
+// formats the spec so it can be parsed by ParseLevelSpec, names are sorted.
+func (levels *LevelSpec) String() string {
+	parts := []string{levels.Default.String()}
+
+	for _, name := range sortedKeys(levels.Names) {
+		parts = append(parts, name+equals+levels.Names[name].String())
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// returns the level for a logger name. Dot separated names use the level of
+// their trailing names, then of their parents and the parents' trailing names,
+// i.e. "a.b.c" uses the first level set of "a.b.c", "b.c", "c", "a.b", "b", "a".
+// Package loggers named below a program's logger, like "t0runner.httpmisc",
+// are set as "httpmisc".
+func (levels *LevelSpec) level(name string) (Level, bool) {
+	for prefix := name; prefix != ""; {
+		for suffix := prefix; ; {
+			if level, ok := levels.Names[suffix]; ok {
+				return level, true
+			}
+
+			i := strings.IndexByte(suffix, '.')
+			if i == -1 {
+				break
+			}
+
+			suffix = suffix[i+1:]
+		}
+
+		i := strings.LastIndexByte(prefix, '.')
+		if i == -1 {
+			break
+		}
+
+		prefix = prefix[:i]
+	}
+
+	return 0, false
+}
+
+func (levels *LevelSpec) clone() *LevelSpec {
+	return &LevelSpec{Default: levels.Default, Names: maps.Clone(levels.Names)}
+}
+
+// returns a copy with all levels moved by step, within LDebug and LNone.
+func (levels *LevelSpec) shift(step int) *LevelSpec {
+	shifted := levels.clone()
+	shifted.Default = shiftLevel(levels.Default, step)
+
+	for name, level := range shifted.Names {
+		shifted.Names[name] = shiftLevel(level, step)
+	}
+
+	return shifted
+}
+
+func shiftLevel(level Level, step int) Level {
+	//nolint:gosec // clamped
+	return Level(max(int(LDebug), min(int(LNone), int(level)+step)))
+}
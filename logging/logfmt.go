@@ -0,0 +1,267 @@
+// Copyright 2024 Raphael Thomazella. All rights reserved.
+// Use of this source code is governed by the BSD-3-Clause
+// license that can be found in the LICENSE file and online
+// at https://opensource.org/license/BSD-3-clause.
+
+package logging
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// keys of the line information in logfmt lines, data follows.
+const (
+	logfmtTime   = "time"
+	logfmtLevel  = "level"
+	logfmtCaller = "caller"
+	logfmtName   = "logger"
+	logfmtMsg    = "msg"
+	// prefixed to data keys equal to line information keys.
+	logfmtDataPrefix = "data."
+)
+
+var ErrInvalidLogfmt = errors.New("invalid logfmt")
+
+// encodes the entry as logfmt, data is sorted by key and follows the
+// line information. Values are quoted and escaped if needed. Data keys
+// equal to line information keys are prefixed with "data.".
+func encodeLogfmt(e *entry) string {
+	var buf strings.Builder
+
+	appendLogfmt(&buf, logfmtTime, e.time.UTC().Format(time.RFC3339Nano))
+	appendLogfmt(&buf, logfmtLevel, e.level.String())
+	appendLogfmt(&buf, logfmtCaller, e.caller())
+
+	if e.name != "" {
+		appendLogfmt(&buf, logfmtName, e.name)
+	}
+
+	appendLogfmt(&buf, logfmtMsg, e.message)
+
+	for _, key := range sortedKeys(e.data) {
+		name := logfmtKey(key)
+		if logfmtReserved(name) {
+			name = logfmtDataPrefix + name
+		}
+
+		appendLogfmt(&buf, name, logfmtValue(e.data[key]))
+	}
+
+	return buf.String()
+}
+
+func appendLogfmt(buf *strings.Builder, key, val string) {
+	if buf.Len() > 0 {
+		buf.WriteByte(' ')
+	}
+
+	buf.WriteString(key)
+	buf.WriteByte('=')
+
+	if val == "" || strings.IndexFunc(val, logfmtNeedsQuote) != -1 {
+		val = strconv.Quote(val)
+	}
+
+	buf.WriteString(val)
+}
+
+// replaces characters not allowed in keys with underscores.
+func logfmtKey(key string) string {
+	if key == "" {
+		return "_"
+	}
+
+	return strings.Map(func(r rune) rune {
+		if r == '=' || logfmtNeedsQuote(r) {
+			return '_'
+		}
+
+		return r
+	}, key)
+}
+
+// strings are kept as is, other values are formatted like text data.
+func logfmtValue(val any) string {
+	if nilPointer(val) {
+		return formatValue(val)
+	}
+
+	switch tVal := val.(type) {
+	case string:
+		return tVal
+	case []byte:
+		return string(tVal)
+	case error:
+		return tVal.Error()
+	case fmt.Stringer:
+		return tVal.String()
+	}
+
+	return formatValue(val)
+}
+
+// reports whether key is a line information key.
+func logfmtReserved(key string) bool {
+	switch key {
+	case logfmtTime, logfmtLevel, logfmtCaller, logfmtName, logfmtMsg:
+		return true
+	}
+
+	return false
+}
+
+func logfmtNeedsQuote(r rune) bool {
+	return r <= ' ' || r == '=' || r == '"' || r == '\\' || unicode.IsSpace(r) || !unicode.IsPrint(r)
+}
+
+// parses a logfmt line into an entry. Time, level, caller, logger and msg
+// keys fill the entry fields, other keys are added to Fields as strings.
+// Keys without a value have an empty value. Lines from OptLogfmt loggers
+// are parsed back with their data formatted as text, and data keys prefixed
+// with "data." to avoid line information keys are restored. The first
+// occurrence of line information keys is kept, for other keys the last.
+func ParseLogfmt(line string) (*Entry, error) {
+	pairs, err := parseLogfmtPairs(line)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &Entry{Fields: map[string]any{}}
+
+	for key, val := range pairs {
+		switch key {
+		default:
+			if name, ok := strings.CutPrefix(key, logfmtDataPrefix); ok && logfmtReserved(name) {
+				key = name
+			}
+
+			e.Fields[key] = val
+		case logfmtTime:
+			e.Time, err = time.Parse(time.RFC3339Nano, val)
+			if err != nil {
+				return nil, fmt.Errorf("%w: time: %w", ErrInvalidLogfmt, err)
+			}
+		case logfmtLevel:
+			e.Level, err = ParseLevel(val)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %w", ErrInvalidLogfmt, err)
+			}
+		case logfmtCaller:
+			e.Caller = val
+		case logfmtName:
+			e.Name = val
+		case logfmtMsg:
+			e.Message = val
+		}
+	}
+
+	if len(e.Fields) == 0 {
+		e.Fields = nil
+	}
+
+	return e, nil
+}
+
+// parses each line of r with ParseLogfmt, empty lines are skipped.
+func ReadLogfmt(r io.Reader) ([]Entry, error) {
+	var entries []Entry
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<20)
+
+	for n := 1; scanner.Scan(); n++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+
+		e, err := ParseLogfmt(scanner.Text())
+		if err != nil {
+			return entries, fmt.Errorf("line %d: %w", n, err)
+		}
+
+		entries = append(entries, *e)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return entries, fmt.Errorf("reading logfmt: %w", err)
+	}
+
+	return entries, nil
+}
+
+// parses key=value pairs, later keys replace earlier ones except line
+// information keys.
+func parseLogfmtPairs(line string) (map[string]string, error) {
+	pairs := map[string]string{}
+	rest := line
+
+	for {
+		rest = strings.TrimLeftFunc(rest, unicode.IsSpace)
+		if rest == "" {
+			return pairs, nil
+		}
+
+		end := strings.IndexFunc(rest, func(r rune) bool { return r == '=' || unicode.IsSpace(r) })
+		if end == -1 {
+			end = len(rest)
+		}
+
+		key := rest[:end]
+		if key == "" {
+			return nil, fmt.Errorf("%w: empty key at %d", ErrInvalidLogfmt, len(line)-len(rest))
+		}
+
+		rest = rest[end:]
+
+		val := ""
+
+		if strings.HasPrefix(rest, "=") {
+			parsed, n, err := parseLogfmtValue(rest[1:])
+			if err != nil {
+				return nil, fmt.Errorf("%w: key %s: %w", ErrInvalidLogfmt, key, err)
+			}
+
+			val = parsed
+			rest = rest[1+n:]
+		}
+
+		if _, seen := pairs[key]; !seen || !logfmtReserved(key) {
+			pairs[key] = val
+		}
+	}
+}
+
+// parses a quoted or bare value at the start of s, returns it and its length in s.
+func parseLogfmtValue(s string) (string, int, error) {
+	if !strings.HasPrefix(s, `"`) {
+		end := strings.IndexFunc(s, unicode.IsSpace)
+		if end == -1 {
+			end = len(s)
+		}
+
+		return s[:end], end, nil
+	}
+
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			val, err := strconv.Unquote(s[:i+1])
+			if err != nil {
+				return "", 0, fmt.Errorf("unquoting: %w", err)
+			}
+
+			return val, i + 1, nil
+		}
+	}
+
+	return "", 0, errors.New("unterminated quote")
+}
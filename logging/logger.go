@@ -6,24 +6,39 @@
 package logging
 
 import (
-	"bytes"
 	"context"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
+	"maps"
 	"runtime"
-	"slices"
-	"strings"
 	"sync/atomic"
-
-	"github.com/tcodes0/go/hue"
+	"time"
 )
 
-// logger wraps log.Logger.
+// a leveled logger writing lines to sinks, or a slog.Handler, see Create.
 type Logger struct {
-	l        *log.Logger
-	exitFunc func(code int) // proxy to os.Exit(1)
-	level    atomic.Int32   // messages are ignored if their level is less
-	color    atomic.Bool    // print terminal color characters
+	core   *core          // shared with loggers derived from this one
+	fields map[string]any // added to the data of every line
+	name   string         // dot separated, selects a level from the level spec
+}
+
+// state shared by a logger and the loggers derived from it with With and Named.
+type core struct {
+	exitFunc  func(code int)            // proxy to os.Exit
+	nower     Nower                     // if set, the time of lines, instead of time.Now
+	handler   slog.Handler              // if set, lines are sent to handler instead of sinks
+	sampler   *sampler                  // if set, repeated lines are sampled
+	async     *asyncQueue               // if set, lines are output by a goroutine
+	hooks     *asyncQueue               // if set, lines are passed to hooks by a goroutine
+	syncHooks []Hook                    // lines are passed to these by the logging goroutine
+	redaction *Redaction                // if set, secrets are removed from data and messages
+	flags     int                       // log package flags, control the text line header
+	level     atomic.Int32              // messages are ignored if their level is less
+	names     atomic.Pointer[LevelSpec] // levels of named loggers, overrides level
+	sinks     []*sink                   // lines are written to every sink
+	shutdown  shutdown                  // callbacks run by fatal methods before exit
+	exitCode  int                       // passed to exitFunc
 }
 
 // set a logger in this context, retrieve it with FromContext.
@@ -31,193 +46,333 @@ func (logger *Logger) WithContext(ctx context.Context) context.Context {
 	return context.WithValue(ctx, contextKey, logger)
 }
 
+// returns a logger that adds fields to the data of every line, in addition
+// to fields of this logger. Sinks and level are shared with this logger.
+// Data passed to *Data methods takes precedence over fields.
+func (logger *Logger) With(fields map[string]any) *Logger {
+	child := &Logger{
+		core:   logger.core,
+		name:   logger.name,
+		fields: make(map[string]any, len(logger.fields)+len(fields)),
+	}
+
+	maps.Copy(child.fields, logger.fields)
+	maps.Copy(child.fields, fields)
+
+	return child
+}
+
+// returns a logger named name, or this logger's name and name joined
+// by a dot. Named loggers use the level set for their name in the level spec,
+// and share everything else with this logger.
+func (logger *Logger) Named(name string) *Logger {
+	if logger.name != "" {
+		name = logger.name + "." + name
+	}
+
+	return &Logger{core: logger.core, fields: logger.fields, name: name}
+}
+
 // sends a message with level info.
 func (logger *Logger) Info(msg ...any) {
-	logger.out(LInfo, msg...)
+	logger.out(LInfo, nil, msg...)
 }
 
 // sends a formatted message with level info.
 func (logger *Logger) Infof(format string, args ...any) {
-	logger.out(LInfo, fmt.Sprintf(format, args...))
+	logger.out(LInfo, nil, fmt.Sprintf(format, args...))
 }
 
-// sends a message with level info and data. Note data ordering is not stable.
+// sends a message with level info and data, sorted by key.
 func (logger *Logger) InfoData(data map[string]any, msg ...any) {
-	logger.out(LInfo, slices.Concat([]any{logger.format(data)}, msg)...)
+	logger.out(LInfo, data, msg...)
 }
 
 // sends a message with level warn.
 func (logger *Logger) Warn(msg ...any) {
-	logger.out(LWarn, msg...)
+	logger.out(LWarn, nil, msg...)
 }
 
 // sends a formatted message with level warn.
 func (logger *Logger) Warnf(format string, args ...any) {
-	logger.out(LWarn, fmt.Sprintf(format, args...))
+	logger.out(LWarn, nil, fmt.Sprintf(format, args...))
 }
 
-// sends a message with level warn and data. Note data ordering is not stable.
+// sends a message with level warn and data, sorted by key.
 func (logger *Logger) WarnData(data map[string]any, msg ...any) {
-	logger.out(LWarn, slices.Concat([]any{logger.format(data)}, msg)...)
+	logger.out(LWarn, data, msg...)
 }
 
 // sends a message with level error.
 func (logger *Logger) Error(msg ...any) {
-	logger.out(LError, msg...)
+	logger.out(LError, nil, msg...)
 }
 
 // sends a formatted message with level error.
 func (logger *Logger) Errorf(format string, args ...any) {
-	logger.out(LError, fmt.Sprintf(format, args...))
+	logger.out(LError, nil, fmt.Sprintf(format, args...))
 }
 
-// sends a message with level error and data. Note data ordering is not stable.
+// sends a message with level error and data, sorted by key.
 func (logger *Logger) ErrorData(data map[string]any, msg ...any) {
-	logger.out(LError, slices.Concat([]any{logger.format(data)}, msg)...)
+	logger.out(LError, data, msg...)
 }
 
 // sends a message with level debug.
 func (logger *Logger) Debug(msg ...any) {
-	logger.out(LDebug, msg...)
+	logger.out(LDebug, nil, msg...)
 }
 
 // sends a formatted message with level debug.
 func (logger *Logger) Debugf(format string, args ...any) {
-	logger.out(LDebug, fmt.Sprintf(format, args...))
+	logger.out(LDebug, nil, fmt.Sprintf(format, args...))
 }
 
-// sends a message with level debug and data. Note data ordering is not stable.
+// sends a message with level debug and data, sorted by key.
 func (logger *Logger) DebugData(data map[string]any, msg ...any) {
-	logger.out(LDebug, slices.Concat([]any{logger.format(data)}, msg)...)
+	logger.out(LDebug, data, msg...)
 }
 
-// sends a message with level fatal and calls the logger exit function.
+// sends a message with level fatal, runs shutdown callbacks and calls the
+// logger exit function, see OnShutdown.
 func (logger *Logger) Fatal(msg ...any) {
-	logger.out(LFatal, msg...)
+	logger.out(LFatal, nil, msg...)
 	logger.exit()
 }
 
-// sends a formatted message with level fatal and calls the logger exit function.
+// sends a formatted message with level fatal, runs shutdown callbacks and
+// calls the logger exit function.
 func (logger *Logger) Fatalf(format string, args ...any) {
-	logger.out(LFatal, fmt.Sprintf(format, args...))
+	logger.out(LFatal, nil, fmt.Sprintf(format, args...))
 	logger.exit()
 }
 
-// sends a message with level fatal, data, and calls the logger exit function.
+// sends a message with level fatal and data, runs shutdown callbacks and
+// calls the logger exit function.
 func (logger *Logger) FatalData(data map[string]any, msg ...any) {
-	logger.out(LFatal, slices.Concat([]any{logger.format(data)}, msg)...)
+	logger.out(LFatal, data, msg...)
+	logger.exit()
 }
 
-func (logger *Logger) format(data map[string]any) string {
-	if len(data) == 0 {
-		return ""
+func (logger *Logger) exit() {
+	if logger.core != nil && logger.core.exitFunc != nil {
+		logger.Flush()
+		logger.runShutdown()
+		// callbacks may log
+		logger.Flush()
+		logger.core.exitFunc(logger.core.exitCode)
+	}
+}
+
+func (logger *Logger) out(msgLevel Level, data map[string]any, msg ...any) {
+	if !logger.enabled(msgLevel) {
+		return
 	}
 
-	color := logger.color.Load()
-	dataMsg := ""
+	// controls stack frames to skip when finding the caller.
+	// 1 runtime.Callers + 1 lib defined + 1 for caller function
+	calldepth := 3
+	pcs := [1]uintptr{}
+	runtime.Callers(calldepth, pcs[:])
+
+	logger.write(newEntry(logger.core.now(), msgLevel, pcs[0], fmt.Sprint(msg...), data))
+}
+
+func (logger *Logger) enabled(msgLevel Level) bool {
+	c := logger.core
+	if c == nil {
+		return false
+	}
 
-	for key, val := range data {
-		sVal := fmt.Sprintf("%v", val)
+	//nolint:gosec // type conversion
+	level := Level(c.level.Load())
 
-		if color {
-			dataMsg += hue.Printc(hue.Brown, key) + hue.Printc(hue.Gray, equals) +
-				hue.Printc(hue.Brown, sVal) + hue.Printc(hue.Gray, comma)
-		} else {
-			dataMsg += key + equals + sVal + comma
+	if names := c.names.Load(); names != nil && logger.name != "" {
+		if named, ok := names.level(logger.name); ok {
+			level = named
 		}
 	}
 
-	if color {
-		return strings.TrimSuffix(dataMsg, comma) + hue.End + " "
+	if msgLevel < level {
+		return false
 	}
 
-	return "(" + strings.TrimSuffix(dataMsg, comma) + ")" + " "
+	return c.handler == nil || c.handler.Enabled(context.Background(), SlogLevel(msgLevel))
 }
 
-func (logger *Logger) exit() {
-	if logger.exitFunc != nil {
-		logger.exitFunc(1)
+// passes an entry to hooks, samples and writes it; level must have been checked.
+func (logger *Logger) write(e *entry) {
+	c := logger.core
+
+	logger.prepare(e)
+	c.fire(e)
+
+	if c.sampler != nil {
+		ok, summaries := c.sampler.sample(logger, e)
+		c.publishSummaries(summaries)
+
+		if !ok {
+			return
+		}
 	}
+
+	c.dispatch(e)
 }
 
-func (logger *Logger) out(msgLevel Level, msg ...any) {
-	//nolint:gosec // type conversion
-	if logger.l == nil || msgLevel < Level(logger.level.Load()) {
-		return
+// writes lines reporting suppressed occurrences, see OptSampling. Hooks
+// received the occurrences and don't receive the summaries.
+func (c *core) publishSummaries(summaries []sampleSummary) {
+	for _, summary := range summaries {
+		summary.logger.prepare(summary.entry)
+		c.dispatch(summary.entry)
 	}
+}
 
-	out := fmt.Sprint(msg...)
+// adds the logger name and fields to e and redacts it.
+func (logger *Logger) prepare(e *entry) {
+	e.name = logger.name
+	e.data = logger.withFields(e.data)
 
-	color := logger.color.Load()
-	if color {
-		// end color of the log line information, started on prefix
-		out = hue.End + out
+	if redaction := logger.core.redaction; redaction != nil {
+		e.data = redaction.Data(e.data)
+		e.message = redaction.String(e.message)
 	}
+}
 
-	//nolint:exhaustive // default handles LInfo and return above on LNone
-	switch msgLevel {
-	default:
-		if color {
-			logger.setPrefix(infoColor)
-		} else {
-			logger.setPrefix(info)
-		}
-	case LWarn:
-		if color {
-			logger.setPrefix(warnColor)
-		} else {
-			logger.setPrefix(warn)
-		}
-	case LError:
-		if color {
-			logger.setPrefix(erroColor)
-		} else {
-			logger.setPrefix(erro)
+// the time of a new line.
+func (c *core) now() time.Time {
+	if c.nower != nil {
+		return c.nower.Now()
+	}
+
+	return time.Now()
+}
+
+// passes the entry to hooks.
+func (c *core) fire(e *entry) {
+	if len(c.syncHooks) > 0 {
+		fire(c.syncHooks, e)
+	}
+
+	if c.hooks != nil {
+		c.hooks.send(e)
+	}
+}
+
+// queues the entry if async is enabled, or outputs it.
+func (c *core) dispatch(e *entry) {
+	if c.async != nil && c.async.send(e) {
+		return
+	}
+
+	c.output(e)
+}
+
+// encodes and writes an entry to sinks or the handler.
+func (c *core) output(e *entry) {
+	if c.handler != nil {
+		err := c.handler.Handle(context.Background(), e.record())
+		if err != nil {
+			c.report(e, err)
 		}
-	case LFatal:
-		if color {
-			logger.setPrefix(fatalColor)
-		} else {
-			logger.setPrefix(fatal)
+
+		return
+	}
+
+	var errs []error
+
+	for i, s := range c.sinks {
+		if e.level < s.level {
+			continue
 		}
-	case LDebug:
-		if color {
-			logger.setPrefix(debugColor)
-		} else {
-			logger.setPrefix(debug)
+
+		err := s.write(e, c.flags)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("sink %d: %w", i, err))
 		}
 	}
 
-	// controls stack frames to pop when showing file:line.
-	// necessary for log.Lshortfile to show correctly
-	// 2 lib defined + 1 for caller function
-	calldepth := 3
+	if len(errs) != 0 {
+		c.report(e, errors.Join(errs...))
+	}
+}
 
-	err := logger.l.Output(calldepth, out)
-	if err != nil {
-		logger.l.SetPrefix(erro)
-		logger.l.Print("printing previous log line: " + err.Error())
+// writes an error line about failing to output e to all sinks, write errors are ignored.
+func (c *core) report(e *entry, err error) {
+	failure := &entry{
+		time:    e.time,
+		level:   LError,
+		pc:      e.pc,
+		file:    e.file,
+		line:    e.line,
+		message: "printing previous log line",
+		data:    map[string]any{"error": err},
+	}
+
+	for _, s := range c.sinks {
+		_ = s.write(failure, c.flags)
 	}
 }
 
-func (logger *Logger) setPrefix(prefix string) {
-	if logger.l.Prefix() != prefix {
-		logger.l.SetPrefix(prefix)
+// merges the logger fields with data, data takes precedence.
+func (logger *Logger) withFields(data map[string]any) map[string]any {
+	if len(logger.fields) == 0 {
+		return data
+	}
+
+	if len(data) == 0 {
+		return logger.fields
 	}
+
+	merged := make(map[string]any, len(logger.fields)+len(data))
+	maps.Copy(merged, logger.fields)
+	maps.Copy(merged, data)
+
+	return merged
 }
 
 // prints a stacktrace as a log message with customizable level.
+// See StackFrames for structured output.
 func (logger *Logger) Stacktrace(level Level, allGoroutines bool) {
-	var stackBuffer bytes.Buffer
-
-	stack := make([]byte, 2048)
-	n := runtime.Stack(stack, allGoroutines)
-	stackBuffer.Write(stack[:n])
+	if !logger.enabled(level) {
+		return
+	}
 
-	logger.out(level, stackBuffer.String())
+	logger.out(level, nil, string(captureStack(allGoroutines)))
 }
 
 // set the level of the logger, lesser messages will be ignored.
+// Levels set for named loggers take precedence, see SetLevelSpec.
 func (logger *Logger) SetLevel(level Level) {
-	logger.level.Store(int32(level))
+	if logger.core != nil {
+		logger.core.level.Store(int32(level))
+	}
+}
+
+// sets the default level and the levels of named loggers, replacing the
+// levels previously set. Affects all loggers sharing this logger's writer.
+func (logger *Logger) SetLevelSpec(levels *LevelSpec) {
+	if logger.core == nil || levels == nil {
+		return
+	}
+
+	logger.core.level.Store(int32(levels.Default))
+	logger.core.names.Store(levels.clone())
+}
+
+// returns a copy of the current default level and levels of named loggers.
+func (logger *Logger) LevelSpec() *LevelSpec {
+	levels := &LevelSpec{Names: map[string]Level{}}
+	if logger.core == nil {
+		return levels
+	}
+
+	//nolint:gosec // type conversion
+	levels.Default = Level(logger.core.level.Load())
+
+	if names := logger.core.names.Load(); names != nil {
+		levels.Names = names.clone().Names
+	}
+
+	return levels
 }
@@ -9,8 +9,11 @@ import (
 	"context"
 	"io"
 	"log"
+	"log/slog"
 	"os"
+	"strconv"
 	"sync/atomic"
+	"time"
 
 	"github.com/tcodes0/go/hue"
 )
@@ -43,6 +46,26 @@ const (
 	comma  = ", "
 )
 
+// lowercase name of the level.
+func (level Level) String() string {
+	switch level {
+	case LDebug:
+		return "debug"
+	case LInfo:
+		return "info"
+	case LWarn:
+		return "warn"
+	case LError:
+		return "error"
+	case LFatal:
+		return "fatal"
+	case LNone:
+		return "none"
+	}
+
+	return "level(" + strconv.Itoa(int(level)) + ")"
+}
+
 type ContextKey struct{}
 
 var (
@@ -66,11 +89,25 @@ func FromContext(ctx context.Context) *Logger {
 }
 
 type createOpts = struct {
-	writer io.Writer
-	exit   func(code int)
-	flags  int
-	level  Level
-	color  bool
+	writer          io.Writer
+	nower           Nower
+	template        *Template
+	console         *Console
+	handler         slog.Handler
+	levels          *LevelSpec
+	sampling        *Sampling
+	async           *Async
+	redaction       *Redaction
+	exit            func(code int)
+	exitCode        *int
+	hooks           []Hook
+	syncHooks       []Hook
+	sinks           []Sink
+	flags           int
+	format          Format
+	shutdownTimeout time.Duration
+	level           Level
+	color           bool
 }
 
 // functional options for creating a logger.
@@ -90,7 +127,32 @@ func OptColor() CreateOptions {
 	}
 }
 
-// option to set the writer for the logger.
+// option to encode each line as a json object with time, level, caller,
+// message and data fields. Color is ignored.
+func OptJSON() CreateOptions {
+	return func(c *createOpts) {
+		c.format = FormatJSON
+	}
+}
+
+// option to encode each line as logfmt, like time=... level=info caller=...
+// msg=... followed by data. Color is ignored, see ParseLogfmt.
+func OptLogfmt() CreateOptions {
+	return func(c *createOpts) {
+		c.format = FormatLogfmt
+	}
+}
+
+// option to send lines to a slog.Handler instead of a writer, the
+// handler's level is checked in addition to the logger's level.
+// Writer, flags, color, json and logfmt options are ignored.
+func OptHandler(handler slog.Handler) CreateOptions {
+	return func(c *createOpts) {
+		c.handler = handler
+	}
+}
+
+// option to set the writer for the logger, log.Writer() by default.
 func OptWriter(w io.Writer) CreateOptions {
 	return func(c *createOpts) {
 		c.writer = w
@@ -112,33 +174,81 @@ func OptLevel(level Level) CreateOptions {
 	}
 }
 
+// option to set the default level and levels of named loggers, overrides OptLevel.
+// A nil spec is ignored.
+func OptLevelSpec(levels *LevelSpec) CreateOptions {
+	return func(c *createOpts) {
+		c.levels = levels
+	}
+}
+
 // creates a new logger with the given options.
 func Create(options ...CreateOptions) *Logger {
 	opts := &createOpts{
-		flags:  defaultFlags,
-		color:  false,
-		writer: log.Writer(),
-		exit:   os.Exit,
-		level:  LInfo,
+		flags: defaultFlags,
+		color: false,
+		exit:  os.Exit,
+		level: LInfo,
 	}
 
 	for _, o := range options {
 		o(opts)
 	}
 
-	prefix := info
-	if opts.color {
-		prefix = hue.Printc(hue.Gray, info)
+	logger := &Logger{core: &core{
+		flags:     opts.flags,
+		handler:   opts.handler,
+		level:     atomic.Int32{},
+		exitFunc:  opts.exit,
+		nower:     opts.nower,
+		exitCode:  defaultExitCode,
+		shutdown:  shutdown{timeout: defaultShutdownTimeout},
+		redaction: opts.redaction,
+	}}
+
+	if opts.writer != nil || len(opts.sinks) == 0 {
+		primary := Sink{
+			Writer:   opts.writer,
+			Format:   opts.format,
+			Color:    opts.color,
+			Template: opts.template,
+			Console:  opts.console,
+		}
+		if primary.Writer == nil {
+			primary.Writer = log.Writer()
+		}
+
+		logger.core.sinks = append(logger.core.sinks, newSink(primary))
+	}
+
+	for _, s := range opts.sinks {
+		logger.core.sinks = append(logger.core.sinks, newSink(s))
+	}
+
+	if opts.sampling != nil {
+		logger.core.sampler = newSampler(*opts.sampling)
 	}
 
-	logger := &Logger{
-		l:        log.New(opts.writer, prefix, opts.flags),
-		color:    atomic.Bool{},
-		level:    atomic.Int32{},
-		exitFunc: opts.exit,
+	if opts.async != nil {
+		logger.core.async = newAsyncQueue(*opts.async, logger.core.output)
 	}
-	logger.color.Store(opts.color)
-	logger.level.Store(int32(opts.level))
+
+	if len(opts.hooks) > 0 {
+		logger.core.hooks = newHookQueue(opts.hooks)
+	}
+
+	logger.core.syncHooks = opts.syncHooks
+
+	if opts.exitCode != nil {
+		logger.core.exitCode = *opts.exitCode
+	}
+
+	if opts.shutdownTimeout > 0 {
+		logger.core.shutdown.timeout = opts.shutdownTimeout
+	}
+
+	logger.core.level.Store(int32(opts.level))
+	logger.SetLevelSpec(opts.levels)
 
 	return logger
 }
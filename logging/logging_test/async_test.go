@@ -0,0 +1,130 @@
+// Copyright 2024 Raphael Thomazella. All rights reserved.
+// Use of this source code is governed by the BSD-3-Clause
+// license that can be found in the LICENSE file and online
+// at https://opensource.org/license/BSD-3-clause.
+
+package logging_test
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tcodes0/go/logging"
+	"golang.org/x/sync/errgroup"
+)
+
+// a writer that blocks until the gate is closed.
+type gateWriter struct {
+	gate chan struct{}
+	out  bytes.Buffer
+	mu   sync.Mutex
+}
+
+func (w *gateWriter) Write(p []byte) (int, error) {
+	<-w.gate
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.out.Write(p)
+}
+
+func (w *gateWriter) String() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.out.String()
+}
+
+func TestAsyncDrop(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	writer := &gateWriter{gate: make(chan struct{})}
+	logger := logging.Create(logging.OptWriter(writer), logging.OptExit(func(int) {}), logging.OptFlags(0),
+		logging.OptAsync(logging.Async{Size: 2, Drop: true}))
+
+	for range 10 {
+		logger.Info("testing")
+	}
+
+	assert.Empty(writer.String())
+	assert.Positive(logger.Dropped())
+
+	close(writer.gate)
+	logger.Flush()
+
+	//nolint:gosec // test
+	assert.Equal(10-int(logger.Dropped()), strings.Count(writer.String(), "testing"))
+}
+
+func TestAsyncBlock(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	out := &bytes.Buffer{}
+	logger := logging.Create(logging.OptWriter(out), logging.OptExit(func(int) {}), logging.OptFlags(0),
+		logging.OptAsync(logging.Async{Size: 1}))
+	data := map[string]any{"n": 1}
+
+	for range 100 {
+		logger.InfoData(data, "testing")
+	}
+
+	data["n"] = 2
+
+	logger.Close()
+	assert.Equal(100, strings.Count(out.String(), info+"(n=1) testing\n"))
+	assert.Zero(logger.Dropped())
+
+	logger.Info("sync")
+	assert.Contains(out.String(), info+"sync\n")
+}
+
+func TestAsyncFatal(t *testing.T) {
+	t.Parallel()
+
+	out := &bytes.Buffer{}
+	exited := ""
+	logger := logging.Create(logging.OptWriter(out), logging.OptFlags(0),
+		logging.OptExit(func(int) { exited = out.String() }),
+		logging.OptAsync(logging.Async{Drop: true}))
+
+	logger.Info("testing")
+	logger.Fatal("testing")
+
+	require.Equal(t, info+"testing\n"+fatal+"testing\n", exited)
+}
+
+func TestAsyncRace(t *testing.T) {
+	t.Parallel()
+
+	out := &bytes.Buffer{}
+	logger := logging.Create(logging.OptWriter(out), logging.OptExit(func(int) {}),
+		logging.OptAsync(logging.Async{Size: 4}))
+	group := errgroup.Group{}
+
+	for range 4 {
+		group.Go(func() error {
+			for range 50 {
+				logger.Info("routine")
+			}
+
+			logger.Flush()
+
+			return nil
+		})
+	}
+
+	group.Go(func() error {
+		logger.Close()
+
+		return nil
+	})
+
+	require.NoError(t, group.Wait(), "wait")
+	require.Equal(t, 200, strings.Count(out.String(), "routine"))
+}
@@ -0,0 +1,96 @@
+// Copyright 2024 Raphael Thomazella. All rights reserved.
+// Use of this source code is governed by the BSD-3-Clause
+// license that can be found in the LICENSE file and online
+// at https://opensource.org/license/BSD-3-clause.
+
+package logging_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tcodes0/go/logging"
+)
+
+func TestOptConsole(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	out := &bytes.Buffer{}
+	now := staticClock{now: time.Date(2024, 9, 14, 13, 4, 5, 120000000, time.Local)}
+	logger := logging.Create(logging.OptWriter(out), logging.OptConsole(logging.Console{MaxValue: 20}),
+		logging.OptClock(now), logging.OptLevel(logging.LDebug))
+
+	logger.InfoData(map[string]any{"status": 500, "url": "http://a.com/b c"}, "request failed")
+	logger.Named("httpmisc").DebugData(map[string]any{
+		"body":  "{\n  \"a\": 1\n}\n",
+		"large": strings.Repeat("x", 30),
+	}, "response")
+	logger.Warn("first line\nsecond line")
+
+	// callers are aligned to the widest seen, they have the same width in this file
+	caller := regexp.MustCompile(`console_test\.go:\d+`)
+	lines := caller.ReplaceAllString(out.String(), "console_test.go:NN")
+
+	assert.Equal(
+		"13:04:05.120 INFO  console_test.go:NN request failed status=500 url=\"http://a.com/b c\"\n"+
+			"13:04:05.120 DEBUG console_test.go:NN httpmisc: response large=xxxxxxxxxxxxxxxxxxxx (+10 bytes)\n"+
+			"    body=\n"+
+			"        {\n"+
+			"          \"a\": 1\n"+
+			"        }\n"+
+			"13:04:05.120 WARN  console_test.go:NN first line\n"+
+			"        second line\n",
+		lines)
+}
+
+func TestOptConsoleTruncateUnicode(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	out := &bytes.Buffer{}
+	logger := logging.Create(logging.OptWriter(out), logging.OptConsole(logging.Console{MaxValue: 4, TimeFormat: "-"}))
+
+	// "çç" is 4 bytes, the third ç would be split
+	logger.InfoData(map[string]any{"a": "ççç", "b": "ab"}, "m")
+	assert.Regexp(`^- INFO  console_test\.go:\d+ m a=çç \(\+2 bytes\) b=ab\n$`, out.String())
+
+	out.Reset()
+	logger = logging.Create(logging.OptWriter(out), logging.OptConsole(logging.Console{MaxValue: -1, TimeFormat: "-"}))
+	logger.InfoData(map[string]any{"a": strings.Repeat("x", 5000)}, "m")
+	assert.NotContains(out.String(), "bytes)")
+}
+
+func TestOptConsoleColor(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	out := &bytes.Buffer{}
+	logger := logging.Create(logging.OptWriter(out), logging.OptConsole(logging.Console{}))
+
+	logger.InfoData(map[string]any{"a": 1}, "not a terminal")
+	assert.NotContains(out.String(), "\033[")
+
+	out.Reset()
+	logger = logging.Create(logging.OptWriter(out), logging.OptConsole(logging.Console{}), logging.OptColor())
+	logger.ErrorData(map[string]any{"a": 1}, "forced")
+	assert.Regexp(rawANSI+"ERROR"+rawANSI, out.String())
+
+	// files that are not terminals
+	file, err := os.Create(filepath.Join(t.TempDir(), "log"))
+	assert.NoError(err)
+	t.Cleanup(func() { file.Close() })
+
+	logger = logging.Create(logging.OptWriter(file), logging.OptConsole(logging.Console{}))
+	logger.Info("file")
+
+	data, err := os.ReadFile(file.Name())
+	assert.NoError(err)
+	assert.NotContains(string(data), "\033[")
+}
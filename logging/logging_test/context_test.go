@@ -0,0 +1,82 @@
+// Copyright 2024 Raphael Thomazella. All rights reserved.
+// Use of this source code is governed by the BSD-3-Clause
+// license that can be found in the LICENSE file and online
+// at https://opensource.org/license/BSD-3-clause.
+
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tcodes0/go/logging"
+)
+
+func TestContextFields(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	ctx := context.Background()
+
+	assert.Nil(logging.ContextFields(ctx))
+
+	ctx = logging.WithRequestID(ctx, "req-1")
+	assert.Equal(map[string]any{"requestId": "req-1"}, logging.ContextFields(ctx))
+
+	ctx = logging.WithTrace(ctx, "trace-1", "")
+	assert.Equal(map[string]any{"requestId": "req-1", "traceId": "trace-1"}, logging.ContextFields(ctx))
+
+	ctx = logging.WithTrace(ctx, "trace-2", "span-2")
+	assert.Equal(map[string]any{"requestId": "req-1", "traceId": "trace-2", "spanId": "span-2"}, logging.ContextFields(ctx))
+
+	id, ok := logging.RequestID(ctx)
+	assert.True(ok)
+	assert.Equal("req-1", id)
+}
+
+func TestCtxMethods(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	out := &bytes.Buffer{}
+	exitCode := -1
+	logger := logging.Create(logging.OptWriter(out), logging.OptFlags(0), logging.OptLevel(logging.LDebug),
+		logging.OptExit(func(code int) { exitCode = code }))
+	ctx := logging.WithTrace(logging.WithRequestID(context.Background(), "req-1"), "trace-1", "span-1")
+	data := "(requestId=req-1, spanId=span-1, traceId=trace-1) "
+
+	logger.DebugCtx(ctx, "testing")
+	logger.InfoCtx(ctx, "testing")
+	logger.WarnCtx(ctx, "testing")
+	logger.ErrorCtx(ctx, "testing")
+	logger.InfoCtx(context.Background(), "no data")
+	logger.WithCtx(ctx).InfoData(map[string]any{"a": 1}, "with")
+	logger.FatalCtx(ctx, "testing")
+
+	assert.Equal(
+		debug+data+"testing\n"+
+			info+data+"testing\n"+
+			warn+data+"testing\n"+
+			erro+data+"testing\n"+
+			info+"no data\n"+
+			info+"(a=1, requestId=req-1, spanId=span-1, traceId=trace-1) with\n"+
+			fatal+data+"testing\n",
+		out.String())
+	assert.Equal(1, exitCode)
+}
+
+func TestFromContextOr(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	logger := logging.Create()
+	fallback := &logging.Logger{}
+
+	assert.Same(fallback, logging.FromContextOr(context.Background(), fallback))
+	assert.Same(logger, logging.FromContextOr(logger.WithContext(context.Background()), fallback))
+	assert.NotPanics(func() {
+		logging.FromContextOr(context.Background(), fallback).Info("discarded")
+	})
+}
@@ -0,0 +1,154 @@
+// Copyright 2024 Raphael Thomazella. All rights reserved.
+// Use of this source code is governed by the BSD-3-Clause
+// license that can be found in the LICENSE file and online
+// at https://opensource.org/license/BSD-3-clause.
+
+package logging_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tcodes0/go/logging"
+)
+
+func TestErrorFields(t *testing.T) {
+	t.Parallel()
+
+	base := errors.New("connection refused")
+
+	tests := []struct {
+		err      error
+		expected map[string]any
+		name     string
+	}{
+		{
+			name:     "nil",
+			err:      nil,
+			expected: nil,
+		},
+		{
+			name:     "plain",
+			err:      base,
+			expected: map[string]any{"error": "connection refused"},
+		},
+		{
+			name: "wrapped",
+			err:  fmt.Errorf("fetching user: %w", fmt.Errorf("doing request: %w", base)),
+			expected: map[string]any{
+				"error":   "fetching user: doing request: connection refused",
+				"error.0": "fetching user",
+				"error.1": "doing request",
+				"error.2": "connection refused",
+			},
+		},
+		{
+			name: "locations",
+			err:  fmt.Errorf("main.go:40: %w", fmt.Errorf("doing request: %w", fmt.Errorf("client.go:12: %w", base))),
+			expected: map[string]any{
+				"error":   "doing request: connection refused",
+				"error.0": "doing request (main.go:40)",
+				"error.1": "connection refused (client.go:12)",
+			},
+		},
+		{
+			name: "joined",
+			err:  fmt.Errorf("closing: %w", errors.Join(base, fmt.Errorf("flushing: %w", errors.New("disk full")))),
+			expected: map[string]any{
+				"error":       "closing: connection refused\nflushing: disk full",
+				"error.0":     "closing",
+				"error.1.0":   "connection refused",
+				"error.1.1.0": "flushing",
+				"error.1.1.1": "disk full",
+			},
+		},
+		{
+			name: "data",
+			err: fmt.Errorf("fetching user: %w",
+				logging.AttachData(base, map[string]any{"user": 7, "error": "ignored"})),
+			expected: map[string]any{
+				"error":   "fetching user: connection refused",
+				"error.0": "fetching user",
+				"error.1": "connection refused",
+				"user":    7,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, test.expected, logging.ErrorFields(test.err))
+		})
+	}
+}
+
+func TestErrorFieldsStack(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	err := fmt.Errorf("fetching user: %w", logging.AttachStack(errors.New("connection refused")))
+	fields := logging.ErrorFields(err)
+
+	stack, ok := fields["error.stack"].([]byte)
+	assert.True(ok)
+	assert.Contains(string(stack), "TestErrorFieldsStack")
+	assert.Equal("fetching user: connection refused", err.Error())
+	assert.Nil(logging.AttachStack(nil))
+	assert.Nil(logging.AttachData(nil, nil))
+}
+
+func TestErr(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	out := &bytes.Buffer{}
+	exitCode := -1
+	logger := logging.Create(logging.OptWriter(out), logging.OptFlags(0),
+		logging.OptExit(func(code int) { exitCode = code }))
+	err := fmt.Errorf("doing request: %w", errors.New("timeout"))
+
+	logger.Err(err, "fetching user")
+	assert.Equal(
+		erro+"(error=\"doing request: timeout\", error.0=\"doing request\", error.1=timeout) fetching user\n",
+		out.String())
+
+	out.Reset()
+	logger.WarnErr(errors.New("timeout"), "retrying")
+	assert.Equal(warn+"(error=timeout) retrying\n", out.String())
+
+	out.Reset()
+	logger.FatalErr(errors.New("timeout"), "giving up")
+	assert.Equal(fatal+"(error=timeout) giving up\n", out.String())
+	assert.Equal(1, exitCode)
+}
+
+func TestErrJSON(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	out := &bytes.Buffer{}
+	logger := logging.Create(logging.OptWriter(out), logging.OptJSON(), logging.OptExit(func(int) {}))
+	err := logging.AttachData(fmt.Errorf("doing request: %w", errors.New("timeout")), map[string]any{"attempt": 3})
+
+	logger.Err(err, "fetching user")
+
+	line := struct {
+		Data map[string]any `json:"data"`
+		Msg  string         `json:"msg"`
+	}{}
+
+	assert.NoError(json.Unmarshal(out.Bytes(), &line))
+	assert.Equal("fetching user", line.Msg)
+	assert.Equal(map[string]any{
+		"error":   "doing request: timeout",
+		"error.0": "doing request",
+		"error.1": "timeout",
+		"attempt": float64(3),
+	}, line.Data)
+}
@@ -0,0 +1,156 @@
+// Copyright 2024 Raphael Thomazella. All rights reserved.
+// Use of this source code is governed by the BSD-3-Clause
+// license that can be found in the LICENSE file and online
+// at https://opensource.org/license/BSD-3-clause.
+
+package logging_test
+
+import (
+	"bytes"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tcodes0/go/logging"
+)
+
+type spyHook struct {
+	entries []logging.Entry
+	mu      sync.Mutex
+}
+
+func (h *spyHook) Fire(e logging.Entry) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.entries = append(h.entries, e)
+}
+
+type blockingHook struct {
+	release chan struct{}
+}
+
+func (h *blockingHook) Fire(logging.Entry) {
+	<-h.release
+}
+
+func TestOptHook(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	spy := &spyHook{}
+	exitCode := -1
+	logger := logging.Create(logging.OptWriter(io.Discard), logging.OptHook(spy),
+		logging.OptExit(func(code int) { exitCode = code }))
+	before := time.Now()
+
+	logger.Debug("filtered")
+	logger.Named("db").With(map[string]any{"a": 1}).WarnData(map[string]any{"b": 2}, "slow query")
+	logger.Fatal("stopping")
+
+	// fatal flushes hooks before exit
+	assert.Equal(1, exitCode)
+	assert.Len(spy.entries, 2)
+
+	warnEntry := spy.entries[0]
+	assert.Equal(logging.LWarn, warnEntry.Level)
+	assert.Equal("slow query", warnEntry.Message)
+	assert.Equal("db", warnEntry.Name)
+	assert.Equal(map[string]any{"a": 1, "b": 2}, warnEntry.Fields)
+	assert.Regexp(`^hooks_test\.go:\d+$`, warnEntry.Caller)
+	assert.False(warnEntry.Time.Before(before))
+	assert.Equal(logging.LFatal, spy.entries[1].Level)
+}
+
+func TestOptSyncHook(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	spy := &spyHook{}
+	logger := logging.Create(logging.OptWriter(io.Discard), logging.OptSyncHook(spy))
+
+	logger.Debug("filtered")
+	logger.Named("db").Info("testing")
+
+	// no flush needed
+	assert.Len(spy.entries, 1)
+	assert.Equal("db", spy.entries[0].Name)
+	assert.Zero(logger.HooksDropped())
+}
+
+func TestHookSampling(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	out := &bytes.Buffer{}
+	counter := &logging.CountHook{}
+	logger := logging.Create(logging.OptWriter(out), logging.OptFlags(0), logging.OptSyncHook(counter),
+		logging.OptSampling(logging.Sampling{Interval: time.Hour, First: 1}))
+
+	for range 5 {
+		logger.Error("testing")
+	}
+
+	logger.Flush()
+	assert.Equal(uint64(5), counter.Total(logging.LError))
+	assert.Equal(erro+"testing\n"+erro+"(message=testing, suppressed=4) sampling suppressed 4 lines\n", out.String())
+}
+
+func TestOptHookNonBlocking(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	hook := &blockingHook{release: make(chan struct{})}
+	counter := &logging.CountHook{}
+	logger := logging.Create(logging.OptWriter(io.Discard), logging.OptHook(hook), logging.OptSyncHook(counter))
+	done := make(chan struct{})
+
+	go func() {
+		// more lines than the hook queue holds
+		for range 5000 {
+			logger.Info("testing")
+		}
+
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		assert.FailNow("logging blocked on a hook")
+	}
+
+	close(hook.release)
+	logger.Flush()
+	// the sync hook counts every line
+	assert.Equal(uint64(5000), counter.Total(logging.LInfo))
+	assert.Positive(logger.HooksDropped())
+	assert.Zero(logger.Dropped())
+	logger.Close()
+}
+
+func TestCountHook(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	counter := &logging.CountHook{}
+	logger := logging.Create(logging.OptWriter(io.Discard), logging.OptSyncHook(counter), logging.OptLevel(logging.LDebug))
+	for range 3 {
+		logger.Error("testing")
+		logger.Named("db").Error("testing")
+	}
+
+	logger.Debug("testing")
+	logger.Named("db").Named("pool").Warn("testing")
+	logger.Flush()
+
+	assert.Equal(uint64(6), counter.Total(logging.LError))
+	assert.Equal(map[logging.Level]uint64{logging.LDebug: 1, logging.LWarn: 1, logging.LError: 6}, counter.Totals())
+	assert.Equal(uint64(3), counter.NameTotal("db", logging.LError))
+	assert.Equal(uint64(3), counter.NameTotal("", logging.LError))
+	assert.Equal(uint64(1), counter.NameTotal("db.pool", logging.LWarn))
+	assert.Equal(uint64(0), counter.NameTotal("db", logging.LWarn))
+	assert.Equal(uint64(0), counter.Total(logging.Level(200)))
+}
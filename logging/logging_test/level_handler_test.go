@@ -0,0 +1,65 @@
+// Copyright 2024 Raphael Thomazella. All rights reserved.
+// Use of this source code is governed by the BSD-3-Clause
+// license that can be found in the LICENSE file and online
+// at https://opensource.org/license/BSD-3-clause.
+
+package logging_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tcodes0/go/logging"
+)
+
+func TestLevelHandler(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	out := &bytes.Buffer{}
+	logger := logging.Create(logging.OptWriter(out), logging.OptFlags(0))
+	handler := logging.LevelHandler(logger)
+
+	serve := func(method, body string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(method, "/levels", strings.NewReader(body)))
+
+		return recorder
+	}
+
+	res := serve(http.MethodGet, "")
+	assert.Equal(http.StatusOK, res.Code)
+	assert.JSONEq(`{"level":"info","names":{}}`, res.Body.String())
+
+	res = serve(http.MethodPut, `{"level":"warn","names":{"httpmisc":"debug"}}`)
+	assert.Equal(http.StatusOK, res.Code)
+	assert.JSONEq(`{"level":"warn","names":{"httpmisc":"debug"}}`, res.Body.String())
+	assert.Equal(warn+"(levels=\"warn,httpmisc=debug\") levels changed\n", out.String())
+
+	out.Reset()
+	logger.Info("suppressed")
+	logger.Named("httpmisc").Debug("shown")
+	assert.Equal(debug+"shown\n", out.String())
+
+	res = serve(http.MethodGet, "")
+	assert.JSONEq(`{"level":"warn","names":{"httpmisc":"debug"}}`, res.Body.String())
+
+	res = serve(http.MethodPut, `{"level":"loud"}`)
+	assert.Equal(http.StatusBadRequest, res.Code)
+	assert.Contains(res.Body.String(), "invalid level")
+
+	res = serve(http.MethodPut, `{"level":"info","names":{"":"debug"}}`)
+	assert.Equal(http.StatusBadRequest, res.Code)
+
+	res = serve(http.MethodPut, `not json`)
+	assert.Equal(http.StatusBadRequest, res.Code)
+
+	res = serve(http.MethodPost, "")
+	assert.Equal(http.StatusMethodNotAllowed, res.Code)
+	assert.Equal("GET, PUT", res.Header().Get("Allow"))
+	assert.Equal(logging.LWarn, logger.LevelSpec().Default)
+}
@@ -0,0 +1,64 @@
+// Copyright 2024 Raphael Thomazella. All rights reserved.
+// Use of this source code is governed by the BSD-3-Clause
+// license that can be found in the LICENSE file and online
+// at https://opensource.org/license/BSD-3-clause.
+
+//go:build !windows
+
+package logging_test
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tcodes0/go/logging"
+)
+
+func TestRoutineLevelSignals(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	// keeps the process alive if a signal arrives before the routine listens
+	ignored := make(chan os.Signal, 2)
+	signal.Notify(ignored, syscall.SIGUSR1, syscall.SIGUSR2)
+	t.Cleanup(func() { signal.Stop(ignored) })
+
+	levels, err := logging.ParseLevelSpec("info,httpmisc=warn")
+	assert.NoError(err)
+
+	logger := logging.Create(logging.OptWriter(io.Discard), logging.OptLevelSpec(levels))
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	timeout := 200 * time.Millisecond
+
+	go func() {
+		logger.RoutineLevelSignals(ctx, timeout)
+		close(done)
+	}()
+
+	// the routine listens shortly after starting
+	time.Sleep(100 * time.Millisecond)
+
+	send := func(sig syscall.Signal, expected string) {
+		assert.NoError(syscall.Kill(syscall.Getpid(), sig))
+		assert.Eventually(func() bool {
+			return logger.LevelSpec().String() == expected
+		}, time.Second, 10*time.Millisecond)
+	}
+
+	send(syscall.SIGUSR1, "debug,httpmisc=info")
+	assert.Eventually(func() bool {
+		return logger.LevelSpec().String() == "info,httpmisc=warn"
+	}, time.Second, 10*time.Millisecond)
+
+	send(syscall.SIGUSR2, "warn,httpmisc=error")
+	cancel()
+	<-done
+	assert.Equal("info,httpmisc=warn", logger.LevelSpec().String())
+}
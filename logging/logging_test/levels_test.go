@@ -0,0 +1,168 @@
+// Copyright 2024 Raphael Thomazella. All rights reserved.
+// Use of this source code is governed by the BSD-3-Clause
+// license that can be found in the LICENSE file and online
+// at https://opensource.org/license/BSD-3-clause.
+
+package logging_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tcodes0/go/logging"
+)
+
+func TestParseLevel(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		want    logging.Level
+		wantErr bool
+	}{
+		{name: "debug", want: logging.LDebug},
+		{name: " INFO ", want: logging.LInfo},
+		{name: "warning", want: logging.LWarn},
+		{name: "5", want: logging.LFatal},
+		{name: "none", want: logging.LNone},
+		{name: "0", wantErr: true},
+		{name: "7", wantErr: true},
+		{name: "loud", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := logging.ParseLevel(test.name)
+			if test.wantErr {
+				require.ErrorIs(t, err, logging.ErrInvalidLevel)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestParseLevelSpec(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		want    *logging.LevelSpec
+		spec    string
+		wantErr bool
+	}{
+		{
+			spec: "",
+			want: &logging.LevelSpec{Default: logging.LInfo, Names: map[string]logging.Level{}},
+		},
+		{
+			spec: "1",
+			want: &logging.LevelSpec{Default: logging.LDebug, Names: map[string]logging.Level{}},
+		},
+		{
+			spec: "info, httpmisc=debug,t0runner=warn",
+			want: &logging.LevelSpec{
+				Default: logging.LInfo,
+				Names:   map[string]logging.Level{"httpmisc": logging.LDebug, "t0runner": logging.LWarn},
+			},
+		},
+		{
+			spec: "httpmisc=error",
+			want: &logging.LevelSpec{Default: logging.LInfo, Names: map[string]logging.Level{"httpmisc": logging.LError}},
+		},
+		{spec: "info,warn", wantErr: true},
+		{spec: "=warn", wantErr: true},
+		{spec: "httpmisc=loud", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.spec, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := logging.ParseLevelSpec(test.spec)
+			if test.wantErr {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.want, got)
+
+			again, err := logging.ParseLevelSpec(got.String())
+			require.NoError(t, err)
+			require.Equal(t, got, again)
+		})
+	}
+}
+
+func TestLoggerNamed(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	out := &bytes.Buffer{}
+	levels, err := logging.ParseLevelSpec("warn,httpmisc=debug")
+	assert.NoError(err)
+
+	logger := logging.Create(logging.OptWriter(out), logging.OptExit(func(int) {}), logging.OptLevelSpec(levels))
+	httpLogger := logger.Named("httpmisc")
+	clientLogger := httpLogger.Named("client").With(map[string]any{"k": "v"})
+	other := logger.Named("other")
+
+	logger.Info("testing")
+	other.Info("testing")
+	assert.Empty(out.String())
+
+	httpLogger.Debug("testing")
+	assert.Contains(out.String(), "DEBUG")
+
+	out.Reset()
+	clientLogger.Debug("testing")
+	assert.Contains(out.String(), "DEBUG")
+
+	levels, err = logging.ParseLevelSpec("debug,httpmisc.client=error")
+	assert.NoError(err)
+	logger.SetLevelSpec(levels)
+	assert.Equal(levels, logger.LevelSpec())
+
+	out.Reset()
+	clientLogger.Warn("testing")
+	assert.Empty(out.String())
+
+	httpLogger.Debug("testing")
+	other.Debug("testing")
+	assert.Equal(2, bytes.Count(out.Bytes(), []byte("DEBUG")))
+}
+
+func TestLoggerNamedNested(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	out := &bytes.Buffer{}
+	levels, err := logging.ParseLevelSpec("info,httpmisc=debug,t0runner=warn")
+	assert.NoError(err)
+
+	logger := logging.Create(logging.OptWriter(out), logging.OptExit(func(int) {}), logging.OptLevelSpec(levels))
+	program := logger.Named("t0runner")
+	// a library naming its logger below the program's
+	library := program.Named("httpmisc")
+
+	program.Info("testing")
+	assert.Empty(out.String())
+
+	library.Debug("testing")
+	assert.Contains(out.String(), "DEBUG")
+
+	out.Reset()
+	library.Named("client").Debug("testing")
+	assert.Contains(out.String(), "DEBUG")
+
+	out.Reset()
+	program.Named("other").Info("testing")
+	assert.Empty(out.String())
+}
@@ -0,0 +1,159 @@
+// Copyright 2024 Raphael Thomazella. All rights reserved.
+// Use of this source code is governed by the BSD-3-Clause
+// license that can be found in the LICENSE file and online
+// at https://opensource.org/license/BSD-3-clause.
+
+package logging_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tcodes0/go/logging"
+)
+
+func TestOptLogfmt(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	out := &bytes.Buffer{}
+	now := staticClock{now: time.Date(2024, 9, 14, 13, 4, 5, 0, time.UTC)}
+	logger := logging.Create(logging.OptWriter(out), logging.OptLogfmt(), logging.OptClock(now))
+
+	logger.Named("db").InfoData(map[string]any{
+		"query":    `select "a"`,
+		"rows":     2,
+		"empty":    "",
+		"err":      errors.New("line 1\nline 2"),
+		"bad key=": "v",
+		"nested":   map[string]any{"a": 1, "b": "c d"},
+	}, "slow query")
+
+	assert.Regexp(`^time=2024-09-14T13:04:05Z level=info caller=logfmt_test\.go:\d+ logger=db msg="slow query" `+
+		`bad_key_=v empty="" err="line 1\\nline 2" nested="{a=1 b=\\"c d\\"}" query="select \\"a\\"" rows=2\n$`, out.String())
+}
+
+func TestParseLogfmt(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	out := &bytes.Buffer{}
+	now := staticClock{now: time.Date(2024, 9, 14, 13, 4, 5, 123000000, time.UTC)}
+	logger := logging.Create(logging.OptWriter(out), logging.OptLogfmt(), logging.OptClock(now))
+
+	logger.WarnData(map[string]any{"path": `C:\tmp "x"`, "n": 1, "unicode": "ção ✓"}, "message = with equals")
+	logger.Named("httpmisc").Error("second")
+
+	entries, err := logging.ReadLogfmt(out)
+	assert.NoError(err)
+	assert.Len(entries, 2)
+
+	assert.Equal(now.now, entries[0].Time)
+	assert.Equal(logging.LWarn, entries[0].Level)
+	assert.Regexp(`^logfmt_test\.go:\d+$`, entries[0].Caller)
+	assert.Equal("message = with equals", entries[0].Message)
+	assert.Empty(entries[0].Name)
+	assert.Equal(map[string]any{"path": `C:\tmp "x"`, "n": "1", "unicode": "ção ✓"}, entries[0].Fields)
+
+	assert.Equal("httpmisc", entries[1].Name)
+	assert.Equal(logging.LError, entries[1].Level)
+	assert.Nil(entries[1].Fields)
+}
+
+func TestLogfmtReservedKeys(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	out := &bytes.Buffer{}
+	now := staticClock{now: time.Date(2024, 9, 14, 13, 4, 5, 0, time.UTC)}
+	logger := logging.Create(logging.OptWriter(out), logging.OptLogfmt(), logging.OptClock(now))
+
+	logger.InfoData(map[string]any{"time": "yesterday", "msg": "other", "level": "x", "logger": "y", "caller": "z"}, "hello")
+	assert.Regexp(`^time=2024-09-14T13:04:05Z level=info caller=logfmt_test\.go:\d+ msg=hello `+
+		`data.caller=z data.level=x data.logger=y data.msg=other data.time=yesterday\n$`, out.String())
+
+	entry, err := logging.ParseLogfmt(out.String())
+	assert.NoError(err)
+	assert.Equal(now.now, entry.Time)
+	assert.Equal(logging.LInfo, entry.Level)
+	assert.Equal("hello", entry.Message)
+	assert.Empty(entry.Name)
+	assert.Equal(map[string]any{"time": "yesterday", "msg": "other", "level": "x", "logger": "y", "caller": "z"}, entry.Fields)
+
+	// the first line information keys win
+	entry, err = logging.ParseLogfmt("msg=first level=warn msg=second level=bad")
+	assert.NoError(err)
+	assert.Equal("first", entry.Message)
+	assert.Equal(logging.LWarn, entry.Level)
+}
+
+func TestParseLogfmtLines(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		expected *logging.Entry
+		line     string
+		name     string
+		wantErr  bool
+	}{
+		{
+			name:     "bare key",
+			line:     `msg=hi  debug a=`,
+			expected: &logging.Entry{Message: "hi", Fields: map[string]any{"debug": "", "a": ""}},
+		},
+		{
+			name:     "escapes",
+			line:     `msg="tab\there \"q\""`,
+			expected: &logging.Entry{Message: "tab\there \"q\""},
+		},
+		{
+			name:    "unterminated",
+			line:    `msg="oops`,
+			wantErr: true,
+		},
+		{
+			name:    "empty key",
+			line:    `=v`,
+			wantErr: true,
+		},
+		{
+			name:    "bad level",
+			line:    `level=loud`,
+			wantErr: true,
+		},
+		{
+			name:    "bad time",
+			line:    `time=yesterday`,
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			e, err := logging.ParseLogfmt(test.line)
+			if test.wantErr {
+				require.ErrorIs(t, err, logging.ErrInvalidLogfmt)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.expected, e)
+		})
+	}
+}
+
+func TestReadLogfmtError(t *testing.T) {
+	t.Parallel()
+
+	entries, err := logging.ReadLogfmt(strings.NewReader("msg=a\n\nmsg=\"b\n"))
+	require.ErrorIs(t, err, logging.ErrInvalidLogfmt)
+	require.ErrorContains(t, err, "line 3")
+	require.Len(t, entries, 1)
+}
@@ -7,11 +7,15 @@ package logging_test
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"reflect"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/tcodes0/go/logging"
@@ -159,7 +163,7 @@ func TestLoggerData(t *testing.T) {
 		out := &bytes.Buffer{}
 		logger := logging.Create(logging.OptWriter(out), logging.OptExit(func(int) {}))
 		testRun(assert, "infoData", logger, []any{"InfoData", mockMap, "testing"}, out, regexp.MustCompile(
-			info+rawFlags+": "+`\(hello|foo=world|bar, hello|foo=world|bar\) `+"testing\n",
+			info+rawFlags+": "+`\(foo=bar, hello=world\) `+"testing\n",
 		))
 	})
 
@@ -169,12 +173,120 @@ func TestLoggerData(t *testing.T) {
 		out := &bytes.Buffer{}
 		logger := logging.Create(logging.OptWriter(out), logging.OptExit(func(int) {}), logging.OptColor())
 		testRun(assert, "errorData color", logger, []any{"ErrorData", mockMap, "testing"}, out, regexp.MustCompile(
-			rawANSI+erro+rawANSI+rawFlags+": "+rawANSI+"hello|foo"+rawANSI+"="+rawANSI+"world|bar"+
-				rawANSI+", "+rawANSI+"foo|hello"+rawANSI+"="+rawANSI+"bar|world"+rawANSI+" testing",
+			rawANSI+erro+rawANSI+rawFlags+": "+rawANSI+"foo"+rawANSI+"="+rawANSI+"bar"+
+				rawANSI+", "+rawANSI+"hello"+rawANSI+"="+rawANSI+"world"+rawANSI+" testing",
 		))
 	})
 }
 
+type stringer struct{ name string }
+
+func (s *stringer) String() string {
+	return s.name
+}
+
+type customErr struct{ msg string }
+
+func (e *customErr) Error() string {
+	return e.msg
+}
+
+func TestLoggerDataFormat(t *testing.T) {
+	t.Parallel()
+
+	type point struct {
+		X, Y   int
+		hidden bool
+	}
+
+	var (
+		nilStringer *stringer
+		nilErr      *customErr
+	)
+
+	tests := []struct {
+		data map[string]any
+		name string
+		want string
+	}{
+		{
+			name: "sorted",
+			data: map[string]any{"c": 3, "a": 1, "b": 2},
+			want: "(a=1, b=2, c=3) ",
+		},
+		{
+			name: "quoted",
+			data: map[string]any{"space": "a b", "comma": "a,b", "equals": "a=b", "empty": "", "plain": "ab"},
+			want: `(comma="a,b", empty="", equals="a=b", plain=ab, space="a b") `,
+		},
+		{
+			name: "types",
+			data: map[string]any{
+				"err":   errors.New("bad thing"),
+				"time":  time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+				"dur":   1500 * time.Millisecond,
+				"stack": []byte("line 1\n\tline 2"),
+				"nil":   nil,
+			},
+			want: `(dur=1.5s, err="bad thing", nil=<nil>, stack="line 1\n\tline 2", time=2024-01-02T03:04:05Z) `,
+		},
+		{
+			name: "nested",
+			data: map[string]any{
+				"map":    map[string]any{"z": 1, "a": "x y"},
+				"slice":  []string{"a", "b c"},
+				"struct": &point{X: 1, Y: 2, hidden: true},
+			},
+			want: `(map={a="x y" z=1}, slice=[a "b c"], struct={X=1 Y=2}) `,
+		},
+		{
+			name: "typed nil",
+			data: map[string]any{"stringer": nilStringer, "err": nilErr},
+			want: "(err=<nil>, stringer=<nil>) ",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			out := &bytes.Buffer{}
+			logger := logging.Create(logging.OptWriter(out), logging.OptExit(func(int) {}), logging.OptFlags(0))
+
+			logger.InfoData(test.data, "testing")
+			require.Equal(t, info+test.want+"testing\n", out.String())
+		})
+	}
+}
+
+func TestLoggerTypedNil(t *testing.T) {
+	t.Parallel()
+
+	var (
+		nilStringer *stringer
+		nilErr      *customErr
+	)
+
+	options := map[string]logging.CreateOptions{
+		"json":    logging.OptJSON(),
+		"logfmt":  logging.OptLogfmt(),
+		"console": logging.OptConsole(logging.Console{}),
+		"text":    logging.OptFlags(0),
+	}
+
+	for name, option := range options {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			out := &bytes.Buffer{}
+			logger := logging.Create(logging.OptWriter(out), option)
+
+			logger.InfoData(map[string]any{"stringer": nilStringer, "err": nilErr}, "testing")
+			require.Contains(t, out.String(), "testing")
+		})
+	}
+}
+
 func TestLoggerLevel(t *testing.T) {
 	t.Parallel()
 
@@ -210,3 +322,80 @@ func TestLoggerLevel(t *testing.T) {
 		})
 	}
 }
+
+func TestLoggerJSON(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	out := &bytes.Buffer{}
+	logger := logging.Create(logging.OptWriter(out), logging.OptExit(func(int) {}), logging.OptJSON())
+
+	logger.WarnData(map[string]any{
+		"count": 2,
+		"ok":    true,
+		"err":   errors.New("failed"),
+		"fn":    func() {},
+	}, "testing")
+
+	line := struct {
+		Data    map[string]any `json:"data"`
+		Time    string         `json:"time"`
+		Level   string         `json:"level"`
+		Caller  string         `json:"caller"`
+		Message string         `json:"msg"`
+	}{}
+
+	assert.NoError(json.Unmarshal(out.Bytes(), &line))
+	assert.Equal("warn", line.Level)
+	assert.Equal("testing", line.Message)
+	assert.Regexp(`^logger_test\.go:\d+$`, line.Caller)
+	assert.Regexp(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}`, line.Time)
+	assert.InDelta(2, line.Data["count"], 0)
+	assert.Equal(true, line.Data["ok"])
+	assert.Equal("failed", line.Data["err"])
+	assert.IsType("", line.Data["fn"])
+
+	out.Reset()
+	logger.Info("testing")
+	assert.NotContains(out.String(), `"data"`)
+	assert.NotContains(out.String(), `"logger"`)
+	assert.Regexp(`^\{.*\}\n$`, out.String())
+
+	out.Reset()
+	logger.Named("httpmisc").Named("client").Info("testing")
+	assert.Contains(out.String(), `"logger":"httpmisc.client"`)
+}
+
+func TestLoggerWith(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	out := &bytes.Buffer{}
+	logger := logging.Create(logging.OptWriter(out), logging.OptExit(func(int) {}), logging.OptJSON())
+	child := logger.With(map[string]any{"request_id": "abc", "user": "foo"})
+	grandchild := child.With(map[string]any{"user": "bar"})
+	line := struct {
+		Data map[string]any `json:"data"`
+	}{}
+
+	logging.FromContext(grandchild.WithContext(context.Background())).InfoData(map[string]any{"n": 1}, "testing")
+	assert.NoError(json.Unmarshal(out.Bytes(), &line))
+	assert.Equal(map[string]any{"request_id": "abc", "user": "bar", "n": float64(1)}, line.Data)
+
+	out.Reset()
+	line.Data = nil
+	child.InfoData(map[string]any{"request_id": "xyz"}, "testing")
+	assert.NoError(json.Unmarshal(out.Bytes(), &line))
+	assert.Equal(map[string]any{"request_id": "xyz", "user": "foo"}, line.Data)
+
+	out.Reset()
+	logger.Info("testing")
+	assert.NotContains(out.String(), "request_id")
+
+	out.Reset()
+	logger.SetLevel(logging.LError)
+	grandchild.Warn("testing")
+	assert.Empty(out.String())
+
+	(&logging.Logger{}).With(map[string]any{"k": "v"}).Fatal("testing")
+}
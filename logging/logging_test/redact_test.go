@@ -0,0 +1,116 @@
+// Copyright 2024 Raphael Thomazella. All rights reserved.
+// Use of this source code is governed by the BSD-3-Clause
+// license that can be found in the LICENSE file and online
+// at https://opensource.org/license/BSD-3-clause.
+
+package logging_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tcodes0/go/logging"
+)
+
+func TestRedactionData(t *testing.T) {
+	t.Parallel()
+
+	redaction := logging.DefaultRedaction()
+
+	tests := []struct {
+		data     map[string]any
+		expected map[string]any
+		name     string
+	}{
+		{
+			name:     "nil",
+			data:     nil,
+			expected: nil,
+		},
+		{
+			name: "keys",
+			data: map[string]any{
+				"Authorization": "Bearer abc", "api_key": 123, "X-Api-Key": "k", "password": "p",
+				"session_token": "t", "cookie": "c", "user": "ann",
+			},
+			expected: map[string]any{
+				"Authorization": logging.Redacted, "api_key": logging.Redacted, "X-Api-Key": logging.Redacted,
+				"password": logging.Redacted, "session_token": logging.Redacted, "cookie": logging.Redacted, "user": "ann",
+			},
+		},
+		{
+			name: "values",
+			data: map[string]any{
+				"header": "Bearer abc.def",
+				"url":    "https://api.com/v1?api_key=secret&page=2",
+				"body":   []byte(`{"name":"ann","password": "hunter2"}`),
+				"err":    errors.New("basic dXNlcjpwYXNz rejected"),
+				"plain":  errors.New("timeout"),
+			},
+			expected: map[string]any{
+				"header": "Bearer " + logging.Redacted,
+				"url":    "https://api.com/v1?api_key=" + logging.Redacted + "&page=2",
+				"body":   `{"name":"ann","password": "` + logging.Redacted + `"}`,
+				"err":    "basic " + logging.Redacted + " rejected",
+				"plain":  errors.New("timeout"),
+			},
+		},
+		{
+			name: "nested",
+			data: map[string]any{
+				"headers": http.Header{"Authorization": {"Bearer abc"}, "Accept": {"text/plain"}},
+				"req":     map[string]any{"cookie": "c", "args": []string{"token=abc", "-v"}},
+				"env":     map[string]string{"GITHUB_TOKEN": "abc", "HOME": "/root"},
+			},
+			expected: map[string]any{
+				"headers": http.Header{"Authorization": {logging.Redacted}, "Accept": {"text/plain"}},
+				"req":     map[string]any{"cookie": logging.Redacted, "args": []string{"token=" + logging.Redacted, "-v"}},
+				"env":     map[string]string{"GITHUB_TOKEN": logging.Redacted, "HOME": "/root"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, test.expected, redaction.Data(test.data))
+		})
+	}
+}
+
+func TestRedactionCustom(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	redaction := &logging.Redaction{
+		Keys:   []*regexp.Regexp{regexp.MustCompile(`^ssn$`)},
+		Values: []*regexp.Regexp{regexp.MustCompile(`\d{3}-\d{2}-\d{4}`)},
+	}
+	header := http.Header{"Authorization": {"Bearer abc"}}
+
+	assert.Equal(map[string]any{"ssn": logging.Redacted, "password": "p", "note": "id " + logging.Redacted},
+		redaction.Data(map[string]any{"ssn": 1, "password": "p", "note": "id 123-45-6789"}))
+	assert.Equal(header, redaction.Header(header))
+	assert.Equal(header, (*logging.Redaction)(nil).Header(header))
+	assert.Equal("Bearer abc", (&logging.Redaction{}).String("Bearer abc"))
+}
+
+func TestOptRedaction(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	out := &bytes.Buffer{}
+	logger := logging.Create(logging.OptWriter(out), logging.OptFlags(0), logging.OptRedaction(logging.DefaultRedaction()))
+	data := map[string]any{"token": "abc", "headers": http.Header{"Cookie": {"a=b"}}}
+
+	logger.With(map[string]any{"password": "p"}).InfoData(data, "sent Bearer abc")
+
+	assert.Equal(info+"(headers={Cookie=["+logging.Redacted+"]}, password="+logging.Redacted+", token="+
+		logging.Redacted+") sent Bearer "+logging.Redacted+"\n", out.String())
+	assert.Equal("abc", data["token"])
+}
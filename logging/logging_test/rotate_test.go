@@ -0,0 +1,203 @@
+// Copyright 2024 Raphael Thomazella. All rights reserved.
+// Use of this source code is governed by the BSD-3-Clause
+// license that can be found in the LICENSE file and online
+// at https://opensource.org/license/BSD-3-clause.
+
+package logging_test
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tcodes0/go/logging"
+	"golang.org/x/sync/errgroup"
+)
+
+func TestRotatingFileSize(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	path := filepath.Join(t.TempDir(), "test.log")
+	file, err := logging.OpenRotatingFile(logging.RotateOptions{Path: path, MaxSize: 10, MaxBackups: 2})
+	assert.NoError(err)
+
+	defer file.Close()
+
+	for _, line := range []string{"line 1\n", "line 2\n", "line 3\n", "line 4\n"} {
+		_, err = file.Write([]byte(line))
+		assert.NoError(err)
+	}
+
+	current, err := os.ReadFile(path)
+	assert.NoError(err)
+	assert.Equal("line 4\n", string(current))
+
+	backups, err := filepath.Glob(path + ".*")
+	assert.NoError(err)
+	assert.Len(backups, 2)
+
+	oldest, err := os.ReadFile(backups[0])
+	assert.NoError(err)
+	assert.Equal("line 2\n", string(oldest))
+}
+
+func TestRotatingFileCompressAge(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	path := filepath.Join(t.TempDir(), "test.log")
+	maxAge := 20 * time.Millisecond
+	file, err := logging.OpenRotatingFile(logging.RotateOptions{Path: path, MaxAge: maxAge, Compress: true})
+	assert.NoError(err)
+
+	logger := logging.Create(logging.OptWriter(file), logging.OptFlags(0))
+	logger.Info("first")
+	time.Sleep(maxAge)
+	logger.Info("second")
+
+	// waits for compression
+	assert.NoError(file.Close())
+
+	backups, err := filepath.Glob(path + ".*.gz")
+	assert.NoError(err)
+	assert.Len(backups, 1)
+
+	gz, err := os.Open(backups[0])
+	assert.NoError(err)
+
+	defer gz.Close()
+
+	reader, err := gzip.NewReader(gz)
+	assert.NoError(err)
+
+	first, err := io.ReadAll(reader)
+	assert.NoError(err)
+	assert.Equal(info+"first\n", string(first))
+
+	current, err := os.ReadFile(path)
+	assert.NoError(err)
+	assert.Equal(info+"second\n", string(current))
+}
+
+func TestRotatingFileCompressPrune(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	path := filepath.Join(t.TempDir(), "test.log")
+	file, err := logging.OpenRotatingFile(logging.RotateOptions{Path: path, MaxBackups: 2, Compress: true})
+	assert.NoError(err)
+
+	for range 5 {
+		_, err = file.Write([]byte("line\n"))
+		assert.NoError(err)
+		assert.NoError(file.Rotate())
+	}
+
+	assert.NoError(file.Close())
+
+	backups, err := filepath.Glob(path + ".[0-9]*")
+	assert.NoError(err)
+	assert.Len(backups, 2)
+
+	for _, backup := range backups {
+		assert.True(strings.HasSuffix(backup, ".gz"), backup)
+	}
+}
+
+func TestRotatingFileRotateError(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	dir := filepath.Join(t.TempDir(), "logs")
+	path := filepath.Join(dir, "test.log")
+	assert.NoError(os.Mkdir(dir, 0o700))
+
+	file, err := logging.OpenRotatingFile(logging.RotateOptions{Path: path, MaxSize: 10})
+	assert.NoError(err)
+
+	defer file.Close()
+
+	_, err = file.Write([]byte("0123456789\n"))
+	assert.NoError(err)
+
+	// the new file can't be opened
+	assert.NoError(os.RemoveAll(dir))
+
+	_, err = file.Write([]byte("line 2\n"))
+	assert.NoError(err)
+	assert.Error(file.Rotate())
+
+	// rotation is tried again after another MaxSize bytes
+	assert.NoError(os.Mkdir(dir, 0o700))
+
+	_, err = file.Write([]byte("line 3\n"))
+	assert.NoError(err)
+
+	current, err := os.ReadFile(path)
+	assert.NoError(err)
+	assert.Equal("line 3\n", string(current))
+}
+
+func TestRotatingFileReopen(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	path := filepath.Join(t.TempDir(), "test.log")
+	file, err := logging.OpenRotatingFile(logging.RotateOptions{Path: path})
+	assert.NoError(err)
+
+	_, err = file.Write([]byte("before\n"))
+	assert.NoError(err)
+	assert.NoError(os.Rename(path, path+".moved"))
+	assert.NoError(file.Reopen())
+
+	_, err = file.Write([]byte("after\n"))
+	assert.NoError(err)
+
+	current, err := os.ReadFile(path)
+	assert.NoError(err)
+	assert.Equal("after\n", string(current))
+
+	assert.NoError(file.Close())
+
+	_, err = file.Write([]byte("closed\n"))
+	assert.ErrorIs(err, os.ErrClosed)
+}
+
+func TestRotatingFileRace(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "test.log")
+	file, err := logging.OpenRotatingFile(logging.RotateOptions{Path: path, MaxSize: 100, MaxBackups: 3})
+	require.NoError(t, err)
+
+	defer file.Close()
+
+	logger := logging.Create(logging.OptWriter(file), logging.OptFlags(0))
+	group := errgroup.Group{}
+
+	for range 4 {
+		group.Go(func() error {
+			for range 50 {
+				logger.Info("routine")
+			}
+
+			return nil
+		})
+	}
+
+	require.NoError(t, group.Wait(), "wait")
+
+	current, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	for _, line := range strings.Split(strings.TrimSpace(string(current)), "\n") {
+		require.Equal(t, info+"routine", line)
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright 2024 Raphael Thomazella. All rights reserved.
+// Use of this source code is governed by the BSD-3-Clause
+// license that can be found in the LICENSE file and online
+// at https://opensource.org/license/BSD-3-clause.
+
+package logging_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tcodes0/go/logging"
+	"golang.org/x/sync/errgroup"
+)
+
+func TestSampling(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	out := &bytes.Buffer{}
+	logger := logging.Create(logging.OptWriter(out), logging.OptExit(func(int) {}), logging.OptFlags(0),
+		logging.OptSampling(logging.Sampling{Interval: time.Hour, First: 2, Thereafter: 3, Bypass: logging.LError}))
+
+	for range 10 {
+		logger.Warn("testing")
+		logger.Error("bypass")
+	}
+
+	logger.Warn("other")
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Equal(10, strings.Count(out.String(), erro+"bypass"))
+	// occurrences 1, 2, 5, 8
+	assert.Equal(4, strings.Count(out.String(), warn+"testing"))
+	assert.Equal(warn+"other", lines[len(lines)-1])
+}
+
+func TestSamplingSuppressed(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	out := &bytes.Buffer{}
+	interval := 50 * time.Millisecond
+	logger := logging.Create(logging.OptWriter(out), logging.OptExit(func(int) {}), logging.OptFlags(0),
+		logging.OptSampling(logging.Sampling{Interval: interval, First: 1}))
+
+	for range 5 {
+		logger.Info("testing")
+	}
+
+	time.Sleep(interval)
+	logger.Info("testing")
+
+	assert.Equal(
+		info+"testing\n"+
+			info+"(message=testing, suppressed=4) sampling suppressed 4 lines\n"+
+			info+"testing\n",
+		out.String(),
+	)
+}
+
+func TestSamplingRace(t *testing.T) {
+	t.Parallel()
+
+	out := &bytes.Buffer{}
+	logger := logging.Create(logging.OptWriter(out), logging.OptExit(func(int) {}),
+		logging.OptSampling(logging.Sampling{Interval: time.Millisecond, First: 1, Thereafter: 2}))
+	group := errgroup.Group{}
+
+	for range 4 {
+		group.Go(func() error {
+			for range 100 {
+				logger.Info("routine")
+			}
+
+			return nil
+		})
+	}
+
+	require.NoError(t, group.Wait(), "wait")
+}
+
+func TestSamplingSummaries(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	out := &bytes.Buffer{}
+	interval := 50 * time.Millisecond
+	logger := logging.Create(logging.OptWriter(out), logging.OptExit(func(int) {}), logging.OptFlags(0),
+		logging.OptSampling(logging.Sampling{Interval: interval, First: 1}))
+
+	for range 5 {
+		logger.Warn("hot")
+	}
+
+	// another message after the window ended
+	time.Sleep(interval)
+	logger.Info("other")
+
+	assert.Equal(
+		warn+"hot\n"+
+			warn+"(message=hot, suppressed=4) sampling suppressed 4 lines\n"+
+			info+"other\n",
+		out.String(),
+	)
+
+	out.Reset()
+
+	named := logger.Named("loop")
+	for range 3 {
+		named.Warn("hot")
+	}
+
+	logger.Flush()
+	assert.Equal(warn+"hot\n"+warn+"(message=hot, suppressed=2) sampling suppressed 2 lines\n", out.String())
+
+	// counts were reported
+	out.Reset()
+	logger.Close()
+	assert.Empty(out.String())
+}
+
+func TestSamplingFatal(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	out := &bytes.Buffer{}
+	logger := logging.Create(logging.OptWriter(out), logging.OptExit(func(int) {}), logging.OptFlags(0),
+		logging.OptSampling(logging.Sampling{Interval: time.Hour}))
+
+	for range 3 {
+		logger.Fatal("stopping")
+	}
+
+	assert.Equal(3, strings.Count(out.String(), fatal+"stopping"))
+	assert.NotContains(out.String(), "suppressed")
+}
+
+func TestSamplingCap(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	out := &bytes.Buffer{}
+	logger := logging.Create(logging.OptWriter(out), logging.OptExit(func(int) {}), logging.OptFlags(0),
+		logging.OptSampling(logging.Sampling{Interval: time.Hour, First: 1}))
+
+	for i := range 2000 {
+		logger.Infof("message %d", i)
+		logger.Infof("message %d", i)
+	}
+
+	// the second occurrence of the first 1024 messages is suppressed
+	assert.Equal(1024+2*976, strings.Count(out.String(), "\n"))
+}
@@ -0,0 +1,111 @@
+// Copyright 2024 Raphael Thomazella. All rights reserved.
+// Use of this source code is governed by the BSD-3-Clause
+// license that can be found in the LICENSE file and online
+// at https://opensource.org/license/BSD-3-clause.
+
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tcodes0/go/logging"
+)
+
+func TestFatalData(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	out := &bytes.Buffer{}
+	exitCode := -1
+	logger := logging.Create(logging.OptWriter(out), logging.OptFlags(0),
+		logging.OptExit(func(code int) { exitCode = code }))
+
+	logger.FatalData(map[string]any{"a": 1}, "testing")
+	assert.Equal(fatal+"(a=1) testing\n", out.String())
+	assert.Equal(1, exitCode)
+}
+
+func TestOptExitCode(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	exitCode := -1
+	logger := logging.Create(logging.OptWriter(&bytes.Buffer{}), logging.OptExitCode(3),
+		logging.OptExit(func(code int) { exitCode = code }))
+
+	logger.Fatal("testing")
+	assert.Equal(3, exitCode)
+}
+
+func TestOnShutdown(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	out := &bytes.Buffer{}
+	calls := []string{}
+	logger := logging.Create(logging.OptWriter(out), logging.OptFlags(0),
+		logging.OptExit(func(int) { calls = append(calls, "exit") }))
+	ctx, cancel := context.WithCancel(context.Background())
+
+	logger.OnShutdown(func(context.Context) error {
+		cancel()
+		calls = append(calls, "cancel")
+
+		return nil
+	})
+	logger.Named("server").OnShutdown(func(ctx context.Context) error {
+		_, ok := ctx.Deadline()
+		assert.True(ok)
+
+		calls = append(calls, "close server")
+
+		return errors.New("already closed")
+	})
+
+	logger.Fatalf("failed %d", 1)
+
+	assert.Equal([]string{"close server", "cancel", "exit"}, calls)
+	assert.Error(ctx.Err())
+	assert.Equal(
+		fatal+"failed 1\n"+
+			erro+"(error=\"already closed\") shutdown callback\n",
+		out.String())
+
+	// callbacks run once
+	logger.Fatal("again")
+	assert.Equal([]string{"close server", "cancel", "exit", "exit"}, calls)
+}
+
+func TestOptShutdownTimeout(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	out := &bytes.Buffer{}
+	exited := make(chan struct{})
+	release := make(chan struct{})
+	logger := logging.Create(logging.OptWriter(out), logging.OptFlags(0),
+		logging.OptShutdownTimeout(50*time.Millisecond), logging.OptExit(func(int) { close(exited) }))
+
+	t.Cleanup(func() { close(release) })
+	logger.OnShutdown(func(context.Context) error {
+		// ignores the deadline
+		<-release
+
+		return nil
+	})
+
+	start := time.Now()
+
+	logger.Fatal("testing")
+	<-exited
+	assert.Less(time.Since(start), time.Second)
+	assert.Equal(
+		fatal+"testing\n"+
+			erro+"(error=\"context deadline exceeded\") shutdown callbacks timed out\n",
+		out.String())
+}
@@ -0,0 +1,94 @@
+// Copyright 2024 Raphael Thomazella. All rights reserved.
+// Use of this source code is governed by the BSD-3-Clause
+// license that can be found in the LICENSE file and online
+// at https://opensource.org/license/BSD-3-clause.
+
+package logging_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tcodes0/go/logging"
+)
+
+type failWriter struct{}
+
+func (failWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestSinks(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	text := &bytes.Buffer{}
+	jsonOut := &bytes.Buffer{}
+	errOut := &bytes.Buffer{}
+	logger := logging.Create(
+		logging.OptExit(func(int) {}),
+		logging.OptLevel(logging.LDebug),
+		logging.OptSink(logging.Sink{Writer: text, Level: logging.LInfo, Color: true}),
+		logging.OptSink(logging.Sink{Writer: jsonOut, Format: logging.FormatJSON}),
+		logging.OptSink(logging.Sink{Writer: errOut, Level: logging.LError}),
+	)
+
+	logger.Debug("testing")
+	logger.Info("testing")
+	logger.Error("testing")
+
+	assert.Equal(2, strings.Count(text.String(), "testing"))
+	assert.NotContains(text.String(), debug)
+	assert.Regexp(regexp.MustCompile(rawANSI+info+rawFlags+": "+rawANSI+"testing\n"), text.String())
+
+	lines := strings.Split(strings.TrimSpace(jsonOut.String()), "\n")
+	assert.Len(lines, 3)
+
+	for _, line := range lines {
+		assert.True(json.Valid([]byte(line)), line)
+	}
+
+	assert.Regexp(matchError, errOut.String())
+	assert.Equal(1, strings.Count(errOut.String(), "\n"))
+}
+
+func TestSinksFailure(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	out := &bytes.Buffer{}
+	logger := logging.Create(
+		logging.OptExit(func(int) {}),
+		logging.OptSink(logging.Sink{Writer: failWriter{}}),
+		logging.OptSink(logging.Sink{Writer: out}),
+	)
+
+	logger.Info("testing")
+
+	assert.Regexp(matchInfo, out.String())
+	assert.Regexp(erro+rawFlags+`: \(error="sink 0: disk full"\) printing previous log line`, out.String())
+}
+
+func TestSinksWriter(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	out := &bytes.Buffer{}
+	extra := &bytes.Buffer{}
+	logger := logging.Create(
+		logging.OptExit(func(int) {}),
+		logging.OptWriter(out),
+		logging.OptJSON(),
+		logging.OptSink(logging.Sink{Writer: extra}),
+	)
+
+	logger.Info("testing")
+
+	assert.True(json.Valid(out.Bytes()), out.String())
+	assert.Regexp(matchInfo, extra.String())
+}
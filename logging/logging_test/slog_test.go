@@ -0,0 +1,95 @@
+// Copyright 2024 Raphael Thomazella. All rights reserved.
+// Use of this source code is governed by the BSD-3-Clause
+// license that can be found in the LICENSE file and online
+// at https://opensource.org/license/BSD-3-clause.
+
+package logging_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tcodes0/go/logging"
+)
+
+func TestSlogLevel(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+
+	for i := range uint8(logging.LNone) {
+		level := logging.Level(i + 1)
+		assert.Equal(level, logging.FromSlogLevel(logging.SlogLevel(level)), level.String())
+	}
+
+	assert.Equal(logging.LInfo, logging.FromSlogLevel(slog.LevelInfo+2))
+	assert.Equal(logging.LDebug, logging.FromSlogLevel(slog.LevelDebug-4))
+}
+
+func TestHandler(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	out := &bytes.Buffer{}
+	logger := logging.Create(logging.OptWriter(out), logging.OptExit(func(int) {}), logging.OptJSON())
+	slogger := slog.New(logging.NewHandler(logger)).With("a", 1).WithGroup("req")
+
+	slogger.Debug("testing")
+	assert.Empty(out.String())
+
+	slogger.Warn("testing", "id", 2, slog.Group("user", "name", "foo"), slog.Group("empty"))
+
+	line := struct {
+		Data    map[string]any `json:"data"`
+		Level   string         `json:"level"`
+		Caller  string         `json:"caller"`
+		Message string         `json:"msg"`
+	}{}
+
+	assert.NoError(json.Unmarshal(out.Bytes(), &line))
+	assert.Equal("warn", line.Level)
+	assert.Equal("testing", line.Message)
+	assert.Regexp(`^slog_test\.go:\d+$`, line.Caller)
+	assert.Equal(map[string]any{
+		"a": float64(1),
+		"req": map[string]any{
+			"id":   float64(2),
+			"user": map[string]any{"name": "foo"},
+		},
+	}, line.Data)
+
+	out.Reset()
+	logger.SetLevel(logging.LDebug)
+	slogger.Debug("testing")
+	assert.Contains(out.String(), `"level":"debug"`)
+}
+
+func TestOptHandler(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	out := &bytes.Buffer{}
+	handler := slog.NewJSONHandler(out, &slog.HandlerOptions{AddSource: true, Level: slog.LevelWarn})
+	logger := logging.Create(logging.OptHandler(handler), logging.OptExit(func(int) {}), logging.OptLevel(logging.LDebug))
+
+	logger.Info("testing")
+	assert.Empty(out.String())
+
+	logger.ErrorData(map[string]any{"k": "v"}, "testing")
+
+	line := struct {
+		Source  map[string]any `json:"source"`
+		Level   string         `json:"level"`
+		Message string         `json:"msg"`
+		K       string         `json:"k"`
+	}{}
+
+	assert.NoError(json.Unmarshal(out.Bytes(), &line))
+	assert.Equal("ERROR", line.Level)
+	assert.Equal("testing", line.Message)
+	assert.Equal("v", line.K)
+	assert.Regexp(`slog_test\.go$`, line.Source["file"])
+}
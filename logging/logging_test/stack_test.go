@@ -0,0 +1,130 @@
+// Copyright 2024 Raphael Thomazella. All rights reserved.
+// Use of this source code is governed by the BSD-3-Clause
+// license that can be found in the LICENSE file and online
+// at https://opensource.org/license/BSD-3-clause.
+
+package logging_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tcodes0/go/logging"
+)
+
+const sampleStack = `goroutine 1 [running]:
+main.(*Server).handle(0xc000012345, {0x0, 0x0})
+	/src/app/server.go:42 +0x1d
+runtime/debug.Stack()
+	/go/src/runtime/debug/stack.go:26 +0x5e
+main.main()
+	/src/app/main.go:12 +0x25
+
+goroutine 18 [chan receive, 2 minutes]:
+runtime.gopark(...)
+	/go/src/runtime/proc.go:424
+main.worker(...)
+	/src/app/worker.go:7
+...additional frames elided...
+created by main.main in goroutine 1
+	/src/app/main.go:10 +0x45
+`
+
+func TestParseStack(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	goroutines := logging.ParseStack([]byte(sampleStack))
+
+	assert.Equal([]logging.Goroutine{
+		{
+			ID:    1,
+			State: "running",
+			Frames: []logging.Frame{
+				{Function: "main.(*Server).handle", File: "/src/app/server.go", Line: 42},
+				{Function: "runtime/debug.Stack", File: "/go/src/runtime/debug/stack.go", Line: 26},
+				{Function: "main.main", File: "/src/app/main.go", Line: 12},
+			},
+		},
+		{
+			ID:    18,
+			State: "chan receive, 2 minutes",
+			Frames: []logging.Frame{
+				{Function: "runtime.gopark", File: "/go/src/runtime/proc.go", Line: 424},
+				{Function: "main.worker", File: "/src/app/worker.go", Line: 7},
+			},
+			CreatedBy: &logging.Frame{Function: "main.main", File: "/src/app/main.go", Line: 10},
+		},
+	}, goroutines)
+
+	skipped := logging.SkipFrames(goroutines, "runtime.", "runtime/debug.")
+	assert.Len(skipped[0].Frames, 2)
+	assert.Equal("main.main", skipped[0].Frames[1].Function)
+	assert.Equal([]logging.Frame{{Function: "main.worker", File: "/src/app/worker.go", Line: 7}}, skipped[1].Frames)
+	assert.Len(goroutines[0].Frames, 3)
+}
+
+func TestStacktrace(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	out := &bytes.Buffer{}
+	logger := logging.Create(logging.OptWriter(out), logging.OptFlags(0))
+	release := make(chan struct{})
+	wg := sync.WaitGroup{}
+
+	for range 50 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			<-release
+		}()
+	}
+
+	logger.Stacktrace(logging.LInfo, true)
+	close(release)
+	wg.Wait()
+
+	// the old fixed buffer cut traces at 2048 bytes
+	assert.Greater(out.Len(), 2048)
+	assert.Greater(strings.Count(out.String(), "goroutine "), 50)
+	assert.Contains(out.String(), "TestStacktrace")
+
+	out.Reset()
+	logger.Stacktrace(logging.LDebug, true)
+	assert.Empty(out.String())
+}
+
+func TestStackFrames(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	out := &bytes.Buffer{}
+	logger := logging.Create(logging.OptWriter(out), logging.OptJSON())
+
+	logger.StackFrames(logging.LInfo, false)
+
+	line := struct {
+		Data struct {
+			Goroutines []logging.Goroutine `json:"goroutines"`
+		} `json:"data"`
+		Msg string `json:"msg"`
+	}{}
+
+	assert.NoError(json.Unmarshal(out.Bytes(), &line))
+	assert.Equal("stacktrace", line.Msg)
+	assert.Len(line.Data.Goroutines, 1)
+	assert.Equal("running", line.Data.Goroutines[0].State)
+	assert.NotEmpty(line.Data.Goroutines[0].Frames)
+	assert.Contains(line.Data.Goroutines[0].Frames[0].Function, "TestStackFrames")
+
+	for _, frame := range line.Data.Goroutines[0].Frames {
+		assert.NotContains(frame.Function, "runtime.")
+		assert.NotContains(frame.Function, "tcodes0/go/logging.")
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2024 Raphael Thomazella. All rights reserved.
+// Use of this source code is governed by the BSD-3-Clause
+// license that can be found in the LICENSE file and online
+// at https://opensource.org/license/BSD-3-clause.
+
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tcodes0/go/logging"
+)
+
+// a clock.Nower.
+type staticClock struct {
+	now time.Time
+}
+
+func (s staticClock) Now() time.Time {
+	return s.now
+}
+
+func TestOptTemplate(t *testing.T) {
+	t.Parallel()
+
+	now := staticClock{now: time.Date(2024, 9, 14, 13, 4, 5, 123456789, time.FixedZone("BRT", -3*60*60))}
+
+	tests := []struct {
+		template logging.Template
+		expected string // regexp
+		name     string
+	}{
+		{
+			name:     "default",
+			template: logging.Template{UTC: true},
+			expected: `^2024-09-14T16:04:05\.123Z INFO template_test\.go:\d+ \(a=1\) message\n` +
+				`2024-09-14T16:04:05\.123Z WARN template_test\.go:\d+ no fields\n$`,
+		},
+		{
+			name:     "rfc3339 fields after",
+			template: logging.Template{Layout: "{time} [{level}] {message} {fields}", TimeFormat: time.RFC3339, UTC: true},
+			expected: `^2024-09-14T16:04:05Z \[INFO\] message \(a=1\)\n` +
+				`2024-09-14T16:04:05Z \[WARN\] no fields\n$`,
+		},
+		{
+			name:     "level width left",
+			template: logging.Template{Layout: "{level}|{message}", LevelWidth: 5},
+			expected: `^INFO \|message\nWARN \|no fields\n$`,
+		},
+		{
+			name:     "level width right",
+			template: logging.Template{Layout: "{level}|{message}", LevelWidth: 5, LevelAlign: logging.AlignRight},
+			expected: `^ INFO\|message\n WARN\|no fields\n$`,
+		},
+		{
+			name:     "long caller",
+			template: logging.Template{Layout: "{caller} {name}: {message}", LongCaller: true},
+			expected: `^/.+/logging/logging_test/template_test\.go:\d+ test: message\n` +
+				`/.+/logging/logging_test/template_test\.go:\d+ test: no fields\n$`,
+		},
+		{
+			name:     "unknown placeholder",
+			template: logging.Template{Layout: "{level} {unknown} {message"},
+			expected: `^INFO \{unknown\} \{message\nWARN \{unknown\} \{message\n$`,
+		},
+		{
+			name:     "local",
+			template: logging.Template{Layout: "{time} {message}", TimeFormat: time.DateTime},
+			expected: "^" + now.now.Local().Format(time.DateTime) + " message\n" +
+				now.now.Local().Format(time.DateTime) + " no fields\n$",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			out := &bytes.Buffer{}
+			logger := logging.Create(logging.OptWriter(out), logging.OptTemplate(test.template),
+				logging.OptClock(now)).Named("test")
+
+			logger.InfoData(map[string]any{"a": 1}, "message")
+			logger.Warn("no fields")
+
+			require.Regexp(t, test.expected, out.String())
+		})
+	}
+}
+
+func TestOptTemplateColor(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	out := &bytes.Buffer{}
+	logger := logging.Create(logging.OptWriter(out), logging.OptColor(),
+		logging.OptTemplate(logging.Template{Layout: "{level} {fields} {message}"}))
+
+	logger.ErrorData(map[string]any{"a": 1}, "message")
+	assert.Regexp("^"+rawANSI+"ERROR"+rawANSI+" "+rawANSI+"a"+rawANSI+"="+rawANSI+"1"+rawANSI+" message\n$", out.String())
+}
+
+func TestOptClock(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	now := staticClock{now: time.Date(2024, 9, 14, 13, 4, 5, 0, time.UTC)}
+	out := &bytes.Buffer{}
+	logger := logging.Create(logging.OptWriter(out), logging.OptJSON(), logging.OptClock(now))
+
+	logger.Info("message")
+	slog.New(logging.NewHandler(logger)).Handler().Handle(context.Background(), slog.Record{Message: "slog"})
+
+	assert.Equal(2, bytes.Count(out.Bytes(), []byte(`"time":"2024-09-14T13:04:05Z"`)))
+}
@@ -0,0 +1,165 @@
+// Copyright 2024 Raphael Thomazella. All rights reserved.
+// Use of this source code is governed by the BSD-3-Clause
+// license that can be found in the LICENSE file and online
+// at https://opensource.org/license/BSD-3-clause.
+
+package logtest
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tcodes0/go/logging"
+)
+
+// a recorded log line.
+type Entry struct {
+	Time    time.Time
+	Fields  map[string]any
+	Message string
+	// file:line of the log call.
+	Caller string
+	// of the logger, see logging.Logger.Named.
+	Name  string
+	Level logging.Level
+}
+
+// records lines logged by the embedded logger, which does not exit on fatal.
+type Recorder struct {
+	*logging.Logger
+	t       testing.TB
+	entries []Entry
+	fatals  int
+	mu      sync.Mutex
+}
+
+// creates a recorder with a logger at debug level, options are applied after
+// the defaults. Lines are printed with t.Log so failing tests show them.
+func New(t testing.TB, options ...logging.CreateOptions) *Recorder {
+	t.Helper()
+
+	rec := &Recorder{t: t}
+	defaults := []logging.CreateOptions{
+		logging.OptLevel(logging.LDebug),
+		logging.OptWriter(io.Discard),
+		logging.OptSyncHook(hook{rec: rec}),
+		logging.OptExit(rec.exit),
+	}
+
+	rec.Logger = logging.Create(append(defaults, options...)...)
+
+	return rec
+}
+
+// returns a copy of the recorded entries.
+func (rec *Recorder) Entries() []Entry {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	entries := make([]Entry, len(rec.entries))
+	copy(entries, rec.entries)
+
+	return entries
+}
+
+// forgets recorded entries and fatal calls.
+func (rec *Recorder) Reset() {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	rec.entries = nil
+	rec.fatals = 0
+}
+
+// fails the test if no entry has level and a message containing substring.
+func (rec *Recorder) RequireLogged(level logging.Level, substring string) {
+	rec.t.Helper()
+
+	if !rec.logged(level, substring) {
+		rec.t.Fatalf("no %s line containing %q was logged", level, substring)
+	}
+}
+
+// fails the test if an entry has level and a message containing substring.
+func (rec *Recorder) RequireNotLogged(level logging.Level, substring string) {
+	rec.t.Helper()
+
+	if rec.logged(level, substring) {
+		rec.t.Fatalf("a %s line containing %q was logged", level, substring)
+	}
+}
+
+// fails the test if a fatal line was logged or the exit function was called.
+func (rec *Recorder) RequireNoFatal() {
+	rec.t.Helper()
+
+	rec.mu.Lock()
+	fatals := rec.fatals
+	rec.mu.Unlock()
+
+	if fatals > 0 || rec.logged(logging.LFatal, "") {
+		rec.t.Fatalf("fatal was logged")
+	}
+}
+
+func (rec *Recorder) logged(level logging.Level, substring string) bool {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	for _, e := range rec.entries {
+		if e.Level == level && strings.Contains(e.Message, substring) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (rec *Recorder) exit(int) {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	rec.fatals++
+}
+
+func (rec *Recorder) record(e *Entry) {
+	rec.mu.Lock()
+	rec.entries = append(rec.entries, *e)
+	rec.mu.Unlock()
+
+	line := strings.ToUpper(e.Level.String()) + " "
+	if e.Name != "" {
+		line += e.Name + " "
+	}
+
+	line += e.Caller + ": " + e.Message
+	if len(e.Fields) != 0 {
+		line += " " + fmt.Sprint(e.Fields)
+	}
+
+	rec.t.Log(line)
+}
+
+// implements logging.Hook, receives lines from the recorder's logger.
+type hook struct {
+	rec *Recorder
+}
+
+var _ logging.Hook = hook{}
+
+// implementation of logging.Hook. Fields keep the types they were logged with.
+func (h hook) Fire(e logging.Entry) {
+	h.rec.record(&Entry{
+		Time:    e.Time,
+		Fields:  maps.Clone(e.Fields),
+		Message: e.Message,
+		Caller:  e.Caller,
+		Name:    e.Name,
+		Level:   e.Level,
+	})
+}
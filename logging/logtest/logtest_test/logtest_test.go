@@ -0,0 +1,81 @@
+// Copyright 2024 Raphael Thomazella. All rights reserved.
+// Use of this source code is governed by the BSD-3-Clause
+// license that can be found in the LICENSE file and online
+// at https://opensource.org/license/BSD-3-clause.
+
+package logtest_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tcodes0/go/logging"
+	"github.com/tcodes0/go/logging/logtest"
+)
+
+// records failures instead of failing the test.
+type spyT struct {
+	*testing.T
+	failures []string
+	logs     []string
+}
+
+func (s *spyT) Fatalf(format string, args ...any) {
+	s.failures = append(s.failures, fmt.Sprintf(format, args...))
+}
+
+func (s *spyT) Log(args ...any) {
+	s.logs = append(s.logs, fmt.Sprint(args...))
+}
+
+func TestRecorder(t *testing.T) {
+	t.Parallel()
+
+	assert := require.New(t)
+	spy := &spyT{T: t}
+	rec := logtest.New(spy)
+
+	rec.With(map[string]any{"id": 1}).DebugData(map[string]any{"k": "v", "n": uint8(2)}, "testing")
+	rec.Warnf("warn %d", 2)
+
+	entries := rec.Entries()
+	assert.Len(entries, 2)
+	assert.Equal(logging.LDebug, entries[0].Level)
+	assert.Equal("testing", entries[0].Message)
+	assert.Equal(map[string]any{"id": 1, "k": "v", "n": uint8(2)}, entries[0].Fields)
+	assert.Regexp(`^logtest_test\.go:\d+$`, entries[0].Caller)
+	assert.Equal([]string{"DEBUG " + entries[0].Caller + ": testing map[id:1 k:v n:2]", "WARN " + entries[1].Caller + ": warn 2"}, spy.logs)
+
+	rec.Named("db").Info("named")
+	assert.Equal("db", rec.Entries()[2].Name)
+	assert.Regexp(`^INFO db logtest_test\.go:\d+: named$`, spy.logs[2])
+
+	rec.RequireLogged(logging.LWarn, "warn")
+	rec.RequireNotLogged(logging.LError, "")
+	rec.RequireNoFatal()
+	assert.Empty(spy.failures)
+
+	rec.RequireLogged(logging.LInfo, "testing")
+	rec.RequireNotLogged(logging.LDebug, "test")
+	assert.Len(spy.failures, 2)
+
+	rec.Fatal("testing")
+	rec.RequireNoFatal()
+	assert.Len(spy.failures, 3)
+
+	rec.Reset()
+	assert.Empty(rec.Entries())
+}
+
+func TestRecorderOptions(t *testing.T) {
+	t.Parallel()
+
+	rec := logtest.New(t, logging.OptLevel(logging.LWarn))
+
+	rec.Info("testing")
+	rec.Error("testing")
+
+	rec.RequireNotLogged(logging.LInfo, "testing")
+	rec.RequireLogged(logging.LError, "testing")
+}
@@ -0,0 +1,200 @@
+// Copyright 2024 Raphael Thomazella. All rights reserved.
+// Use of this source code is governed by the BSD-3-Clause
+// license that can be found in the LICENSE file and online
+// at https://opensource.org/license/BSD-3-clause.
+
+package logging
+
+import (
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// replaces redacted values and parts of values.
+const Redacted = "REDACTED"
+
+// nested data deeper than this is not redacted.
+const maxRedactDepth = 5
+
+var (
+	// data keys and header names of secrets, see DefaultRedaction.
+	reSecretKey = regexp.MustCompile(`(?i)authorization|cookie|token|password|passwd|secret|api[_-]?key`)
+	// credentials in values, like "Bearer abc123", "password=abc123" or
+	// "password": "abc123"; see DefaultRedaction.
+	reSecretValues = []*regexp.Regexp{
+		regexp.MustCompile(`(?i)\b(?:bearer|basic)\s+([\w.~+/=-]+)`),
+		regexp.MustCompile(`(?i)\b(?:\w*token|password|passwd|secret|api[_-]?key)=([^\s&,;]+)`),
+		regexp.MustCompile(`(?i)"(?:\w*token|password|passwd|secret|api[_-]?key)"\s*:\s*"([^"]*)"`),
+	}
+)
+
+// which data to redact, see OptRedaction.
+type Redaction struct {
+	// the whole value of keys matching any of these is replaced with Redacted.
+	// Applies to nested maps and http.Header names.
+	Keys []*regexp.Regexp
+	// parts of string values matching any of these are replaced with Redacted;
+	// if a pattern has groups only the first group is replaced.
+	Values []*regexp.Regexp
+}
+
+// redacts keys like authorization, cookie, token, password and api_key,
+// and credentials in values: bearer or basic authorization, and secrets
+// in query strings or json.
+func DefaultRedaction() *Redaction {
+	return &Redaction{Keys: []*regexp.Regexp{reSecretKey}, Values: slices.Clone(reSecretValues)}
+}
+
+// option to redact secrets from the data of every line, including fields
+// added with With. Value patterns are also applied to messages.
+func OptRedaction(redaction *Redaction) CreateOptions {
+	return func(c *createOpts) {
+		c.redaction = redaction
+	}
+}
+
+// returns a copy of data with secrets redacted; data is not modified.
+func (r *Redaction) Data(data map[string]any) map[string]any {
+	if r == nil || data == nil {
+		return data
+	}
+
+	redacted := make(map[string]any, len(data))
+
+	for key, val := range data {
+		redacted[key] = r.value(key, val, 0)
+	}
+
+	return redacted
+}
+
+// returns a copy of header with secrets redacted; header is not modified.
+func (r *Redaction) Header(header http.Header) http.Header {
+	if r == nil || header == nil {
+		return header
+	}
+
+	redacted := make(http.Header, len(header))
+
+	for name, values := range header {
+		if r.key(name) {
+			redacted[name] = []string{Redacted}
+
+			continue
+		}
+
+		redacted[name] = r.strings(values)
+	}
+
+	return redacted
+}
+
+// returns s with parts matching the value patterns redacted.
+func (r *Redaction) String(s string) string {
+	if r == nil {
+		return s
+	}
+
+	for _, re := range r.Values {
+		s = redactMatches(re, s)
+	}
+
+	return s
+}
+
+// replaces matches of re in s, or their first group if re has groups.
+func redactMatches(re *regexp.Regexp, s string) string {
+	matches := re.FindAllStringSubmatchIndex(s, -1)
+	if matches == nil {
+		return s
+	}
+
+	var redacted strings.Builder
+
+	last := 0
+
+	for _, match := range matches {
+		start, end := match[0], match[1]
+		if len(match) > 2 && match[2] >= 0 {
+			start, end = match[2], match[3]
+		}
+
+		redacted.WriteString(s[last:start])
+		redacted.WriteString(Redacted)
+
+		last = end
+	}
+
+	redacted.WriteString(s[last:])
+
+	return redacted.String()
+}
+
+func (r *Redaction) key(key string) bool {
+	for _, re := range r.Keys {
+		if re.MatchString(key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *Redaction) value(key string, val any, depth int) any {
+	if r.key(key) {
+		return Redacted
+	}
+
+	if depth >= maxRedactDepth {
+		return val
+	}
+
+	switch tVal := val.(type) {
+	case string:
+		return r.String(tVal)
+	case []string:
+		return r.strings(tVal)
+	case []byte:
+		return r.String(string(tVal))
+	case error:
+		if msg := r.String(tVal.Error()); msg != tVal.Error() {
+			return msg
+		}
+	case http.Header:
+		return r.Header(tVal)
+	case map[string]string:
+		redacted := make(map[string]string, len(tVal))
+
+		for k, v := range tVal {
+			if r.key(k) {
+				redacted[k] = Redacted
+			} else {
+				redacted[k] = r.String(v)
+			}
+		}
+
+		return redacted
+	case map[string]any:
+		redacted := make(map[string]any, len(tVal))
+
+		for k, v := range tVal {
+			redacted[k] = r.value(k, v, depth+1)
+		}
+
+		return redacted
+	}
+
+	return val
+}
+
+func (r *Redaction) strings(values []string) []string {
+	redacted := make([]string, len(values))
+
+	for i, v := range values {
+		redacted[i] = r.String(v)
+	}
+
+	return redacted
+}
@@ -0,0 +1,310 @@
+// Copyright 2024 Raphael Thomazella. All rights reserved.
+// Use of this source code is governed by the BSD-3-Clause
+// license that can be found in the LICENSE file and online
+// at https://opensource.org/license/BSD-3-clause.
+
+package logging
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// appended to the path of rotated files, sorts in rotation order.
+const backupTimeFormat = "20060102T150405.000000000"
+
+// options for OpenRotatingFile.
+type RotateOptions struct {
+	// file to write to, rotated files are placed next to it.
+	Path string
+	// rotate before a write would exceed this many bytes, zero disables.
+	MaxSize int64
+	// rotate a file open for longer than this, zero disables.
+	MaxAge time.Duration
+	// rotated files kept, the oldest are removed first; zero keeps all.
+	MaxBackups int
+	// gzip rotated files.
+	Compress bool
+}
+
+// a file writer that rotates by size or age, safe for concurrent use.
+// Use with OptWriter or Sink.
+type RotatingFile struct {
+	opened      time.Time
+	file        *os.File
+	opts        RotateOptions
+	size        int64
+	compressing sync.WaitGroup // rotated files being compressed
+	background  sync.Mutex     // compression and pruning run one at a time
+	mu          sync.Mutex
+}
+
+var _ io.WriteCloser = (*RotatingFile)(nil)
+
+// opens or creates the file at opts.Path for appending.
+func OpenRotatingFile(opts RotateOptions) (*RotatingFile, error) {
+	if opts.Path == "" {
+		return nil, errors.New("path is required")
+	}
+
+	rotating := &RotatingFile{opts: opts}
+
+	err := rotating.open()
+	if err != nil {
+		return nil, err
+	}
+
+	return rotating, nil
+}
+
+// implementation of io.Writer, rotates the file if needed before writing.
+// Rotation errors are printed to stderr and writing continues.
+func (r *RotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return 0, os.ErrClosed
+	}
+
+	tooBig := r.opts.MaxSize > 0 && r.size > 0 && r.size+int64(len(p)) > r.opts.MaxSize
+	tooOld := r.opts.MaxAge > 0 && time.Since(r.opened) >= r.opts.MaxAge
+
+	if tooBig || tooOld {
+		err := r.rotate()
+		if err != nil {
+			// keep writing to the current file, rotation is tried again
+			// after another MaxSize bytes or MaxAge
+			fmt.Fprintf(os.Stderr, "rotating log file: %s\n", err.Error())
+
+			r.size = 0
+			r.opened = time.Now()
+		}
+	}
+
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+
+	if err != nil {
+		return n, fmt.Errorf("writing %s: %w", r.opts.Path, err)
+	}
+
+	return n, nil
+}
+
+// rotates the file now.
+func (r *RotatingFile) Rotate() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.rotate()
+}
+
+// closes and opens the file again, for when it was moved by another program.
+func (r *RotatingFile) Reopen() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	err := r.close()
+	if err != nil {
+		return err
+	}
+
+	return r.open()
+}
+
+// implementation of io.Closer, waits for rotated files to be compressed.
+// Writes after close fail.
+func (r *RotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	err := r.close()
+	r.compressing.Wait()
+
+	return err
+}
+
+// blocks reopening the file on hang up signals until ctx is done.
+// Errors are printed to stderr.
+func (r *RotatingFile) RoutineReopenOnHangup(ctx context.Context) {
+	signalChan := make(chan os.Signal, 1)
+	signal.Notify(signalChan, syscall.SIGHUP)
+
+	defer signal.Stop(signalChan)
+
+	for {
+		select {
+		case <-signalChan:
+			err := r.Reopen()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "reopening log file: %s\n", err.Error())
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (r *RotatingFile) open() error {
+	file, err := os.OpenFile(r.opts.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("opening %s: %w", r.opts.Path, err)
+	}
+
+	stat, err := file.Stat()
+	if err != nil {
+		file.Close()
+
+		return fmt.Errorf("stat %s: %w", r.opts.Path, err)
+	}
+
+	r.file = file
+	r.size = stat.Size()
+	r.opened = time.Now()
+
+	return nil
+}
+
+func (r *RotatingFile) close() error {
+	if r.file == nil {
+		return nil
+	}
+
+	err := r.file.Close()
+	r.file = nil
+
+	if err != nil {
+		return fmt.Errorf("closing %s: %w", r.opts.Path, err)
+	}
+
+	return nil
+}
+
+// renames the file and opens a new one; on failure the current file is
+// still open.
+func (r *RotatingFile) rotate() error {
+	backup := r.opts.Path + "." + time.Now().UTC().Format(backupTimeFormat)
+
+	err := os.Rename(r.opts.Path, backup)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("renaming %s: %w", r.opts.Path, err)
+	}
+
+	previous := r.file
+
+	// open replaces r.file only on success
+	err = r.open()
+	if err != nil {
+		return err
+	}
+
+	if previous != nil {
+		err = previous.Close()
+		if err != nil {
+			return fmt.Errorf("closing %s: %w", backup, err)
+		}
+	}
+
+	if !r.opts.Compress {
+		return r.prune()
+	}
+
+	// compressing in Write would block loggers, pruning waits so it
+	// doesn't count the uncompressed file
+	r.compressing.Add(1)
+
+	go func() {
+		defer r.compressing.Done()
+
+		r.background.Lock()
+		defer r.background.Unlock()
+
+		err := compress(backup)
+		if err == nil {
+			err = r.prune()
+		}
+
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "rotating log file: %s\n", err.Error())
+		}
+	}()
+
+	return nil
+}
+
+// removes the oldest backups beyond MaxBackups.
+func (r *RotatingFile) prune() error {
+	if r.opts.MaxBackups <= 0 {
+		return nil
+	}
+
+	backups, err := filepath.Glob(r.opts.Path + ".[0-9]*")
+	if err != nil {
+		return fmt.Errorf("listing backups: %w", err)
+	}
+
+	if len(backups) <= r.opts.MaxBackups {
+		return nil
+	}
+
+	slices.Sort(backups)
+
+	for _, backup := range backups[:len(backups)-r.opts.MaxBackups] {
+		err = os.Remove(backup)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("removing backup: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// gzips path to path.gz and removes path.
+func compress(path string) (err error) {
+	src, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("opening %s: %w", path, err)
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(path+".gz", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("creating %s.gz: %w", path, err)
+	}
+
+	defer func() {
+		if closeErr := dst.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("closing %s.gz: %w", path, closeErr)
+		}
+	}()
+
+	zipper := gzip.NewWriter(dst)
+
+	_, err = io.Copy(zipper, src)
+	if err != nil {
+		return fmt.Errorf("compressing %s: %w", path, err)
+	}
+
+	err = zipper.Close()
+	if err != nil {
+		return fmt.Errorf("compressing %s: %w", path, err)
+	}
+
+	err = os.Remove(path)
+	if err != nil {
+		return fmt.Errorf("removing %s: %w", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,169 @@
+// Copyright 2024 Raphael Thomazella. All rights reserved.
+// Use of this source code is governed by the BSD-3-Clause
+// license that can be found in the LICENSE file and online
+// at https://opensource.org/license/BSD-3-clause.
+
+package logging
+
+import (
+	"strconv"
+	"sync"
+	"time"
+)
+
+// messages tracked, new messages past this aren't sampled until expired
+// windows are swept.
+const maxSampled = 1024
+
+// options for sampling repeated messages, see OptSampling.
+type Sampling struct {
+	// length of the sampling window, counts reset every interval.
+	Interval time.Duration
+	// occurrences of a message logged per interval before sampling starts.
+	First int
+	// after First, every Thereafter-th occurrence is logged; zero drops all.
+	Thereafter int
+	// messages with this level or higher are never sampled, zero samples
+	// all levels but fatal, which is never sampled.
+	Bypass Level
+}
+
+// option to sample messages with the same level and text, up to 1024
+// distinct messages per interval; other messages are not sampled. Once a
+// window with suppressed messages ends, a line with the count of suppressed
+// messages is written before the next line, or on Logger.Flush and Logger.Close.
+func OptSampling(sampling Sampling) CreateOptions {
+	return func(c *createOpts) {
+		c.sampling = &sampling
+	}
+}
+
+type sampleKey struct {
+	message string
+	level   Level
+}
+
+type sampleWindow struct {
+	start      time.Time
+	last       *entry  // last suppressed occurrence
+	logger     *Logger // logger of last, adds its name and fields to the summary
+	count      int
+	suppressed int
+}
+
+// a line reporting suppressed occurrences, to be prepared by logger.
+type sampleSummary struct {
+	logger *Logger
+	entry  *entry
+}
+
+type sampler struct {
+	swept   time.Time
+	windows map[sampleKey]*sampleWindow
+	opts    Sampling
+	mu      sync.Mutex
+}
+
+func newSampler(opts Sampling) *sampler {
+	return &sampler{opts: opts, windows: map[sampleKey]*sampleWindow{}}
+}
+
+// reports if the entry logged by logger should be written, and returns
+// summaries of windows that ended, to be written first.
+func (s *sampler) sample(logger *Logger, e *entry) (ok bool, summaries []sampleSummary) {
+	// a process must not exit with its reason suppressed
+	if e.level >= LFatal || (s.opts.Bypass != 0 && e.level >= s.opts.Bypass) {
+		return true, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e.time.Sub(s.swept) >= s.opts.Interval {
+		summaries = s.sweep(e.time, false)
+	}
+
+	key := sampleKey{message: e.message, level: e.level}
+	window := s.windows[key]
+
+	// many distinct messages, like formatted ones, would grow the map
+	if window == nil && len(s.windows) >= maxSampled {
+		return true, summaries
+	}
+
+	if window == nil || e.time.Sub(window.start) >= s.opts.Interval {
+		if window != nil && window.suppressed > 0 {
+			summaries = append(summaries, window.summary(e.time))
+		}
+
+		window = &sampleWindow{start: e.time}
+		s.windows[key] = window
+	}
+
+	window.count++
+
+	after := window.count - s.opts.First
+	if after <= 0 || (s.opts.Thereafter > 0 && after%s.opts.Thereafter == 0) {
+		return true, summaries
+	}
+
+	window.suppressed++
+	window.last = e
+	window.logger = logger
+
+	return false, summaries
+}
+
+// returns summaries of pending suppressed counts and resets them.
+func (s *sampler) flush(now time.Time) []sampleSummary {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.sweep(now, true)
+}
+
+// removes expired windows and returns summaries of their suppressed counts,
+// or of all windows if all is set. Must hold mu.
+func (s *sampler) sweep(now time.Time, all bool) []sampleSummary {
+	var summaries []sampleSummary
+
+	s.swept = now
+
+	for key, window := range s.windows {
+		expired := now.Sub(window.start) >= s.opts.Interval
+		if window.suppressed > 0 && (expired || all) {
+			summaries = append(summaries, window.summary(now))
+			window.suppressed = 0
+		}
+
+		if expired {
+			delete(s.windows, key)
+		}
+	}
+
+	return summaries
+}
+
+// a summary of the window's suppressed occurrences at time now.
+func (window *sampleWindow) summary(now time.Time) sampleSummary {
+	summary := suppressedEntry(window.last, window.suppressed)
+	summary.time = now
+
+	return sampleSummary{logger: window.logger, entry: summary}
+}
+
+// a line reporting suppressed occurrences of e's message.
+func suppressedEntry(e *entry, suppressed int) *entry {
+	msg := "sampling suppressed " + strconv.Itoa(suppressed) + " lines"
+	data := map[string]any{"message": e.message, "suppressed": suppressed}
+
+	return &entry{
+		time:    e.time,
+		level:   e.level,
+		pc:      e.pc,
+		file:    e.file,
+		line:    e.line,
+		message: msg,
+		data:    data,
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2024 Raphael Thomazella. All rights reserved.
+// Use of this source code is governed by the BSD-3-Clause
+// license that can be found in the LICENSE file and online
+// at https://opensource.org/license/BSD-3-clause.
+
+package logging
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// exit code of fatal methods if OptExitCode is not used.
+	defaultExitCode = 1
+	// time shutdown callbacks have to finish if OptShutdownTimeout is not used.
+	defaultShutdownTimeout = 5 * time.Second
+)
+
+// option to set the code fatal methods exit with, 1 by default.
+func OptExitCode(code int) CreateOptions {
+	return func(c *createOpts) {
+		c.exitCode = &code
+	}
+}
+
+// option to set how long fatal methods wait for shutdown callbacks before
+// exiting, 5 seconds by default, see Logger.OnShutdown.
+func OptShutdownTimeout(timeout time.Duration) CreateOptions {
+	return func(c *createOpts) {
+		c.shutdownTimeout = timeout
+	}
+}
+
+// callbacks registered with OnShutdown.
+type shutdown struct {
+	callbacks []func(ctx context.Context) error
+	timeout   time.Duration
+	mu        sync.Mutex
+}
+
+// registers fn to run before fatal methods exit; fn should stop once ctx
+// is done, at the shutdown timeout. Callbacks run one at a time, the last
+// registered first; errors are logged. Use it to close servers, flush
+// writers, or cancel a context to stop goroutines.
+// Affects all loggers sharing this logger's writer.
+func (logger *Logger) OnShutdown(fn func(ctx context.Context) error) {
+	if logger.core == nil || fn == nil {
+		return
+	}
+
+	s := &logger.core.shutdown
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.callbacks = append(s.callbacks, fn)
+}
+
+// runs the shutdown callbacks until they return or the timeout passes.
+func (logger *Logger) runShutdown() {
+	s := &logger.core.shutdown
+
+	s.mu.Lock()
+	callbacks := s.callbacks
+	// a fatal line logged by a callback must not run callbacks again
+	s.callbacks = nil
+	s.mu.Unlock()
+
+	if len(callbacks) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		for i := len(callbacks) - 1; i >= 0; i-- {
+			if ctx.Err() != nil {
+				return
+			}
+
+			if err := callbacks[i](ctx); err != nil {
+				logger.Err(err, "shutdown callback")
+			}
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		logger.Err(ctx.Err(), "shutdown callbacks timed out")
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2024 Raphael Thomazella. All rights reserved.
+// Use of this source code is governed by the BSD-3-Clause
+// license that can be found in the LICENSE file and online
+// at https://opensource.org/license/BSD-3-clause.
+
+package logging
+
+import (
+	"io"
+	"log"
+)
+
+// how a sink encodes lines.
+type Format uint8
+
+const (
+	// log package style text, the default.
+	FormatText Format = iota
+	// one json object per line, see OptJSON.
+	FormatJSON
+	// key=value pairs, see OptLogfmt.
+	FormatLogfmt
+	// aligned columns for people reading a terminal, see OptConsole.
+	FormatConsole
+)
+
+// a destination for log lines, see OptSink.
+type Sink struct {
+	Writer io.Writer
+	// lines with a lower level are not written to this sink; lines must
+	// pass the logger's level first. Zero writes all lines.
+	Level  Level
+	Format Format
+	// if set, text lines follow it instead of the logger flags, see OptTemplate.
+	Template *Template
+	// options of the console format, defaults are used if nil.
+	Console *Console
+	// print terminal color characters, ignored by json and logfmt.
+	// The console format also prints colors if the writer is a terminal.
+	Color bool
+}
+
+// option to add a sink, lines are written to all sinks. If sinks are added
+// the writer, color, format and template options only apply if OptWriter is used.
+// A failure writing to a sink is reported as an error line to all sinks.
+func OptSink(s Sink) CreateOptions {
+	return func(c *createOpts) {
+		c.sinks = append(c.sinks, s)
+	}
+}
+
+type sink struct {
+	l        *log.Logger   // serializes writes
+	template *textTemplate // if set, encodes text lines
+	console  *consoleEncoder
+	level    Level
+	format   Format
+	color    bool
+}
+
+func newSink(s Sink) *sink {
+	var template *textTemplate
+	if s.Template != nil {
+		template = newTextTemplate(*s.Template)
+	}
+
+	var console *consoleEncoder
+	if s.Format == FormatConsole {
+		console = newConsoleEncoder(s.Console)
+		s.Color = s.Color || isTerminal(s.Writer)
+	}
+
+	return &sink{
+		// header and prefix are encoded by the logger
+		l:        log.New(s.Writer, "", 0),
+		template: template,
+		console:  console,
+		level:    s.Level,
+		format:   s.Format,
+		color:    s.Color,
+	}
+}
+
+// encodes and writes e, flags control the text header.
+func (s *sink) write(e *entry, flags int) error {
+	var line string
+
+	//nolint:exhaustive // default handles FormatText
+	switch s.format {
+	default:
+		if s.template != nil {
+			line = s.template.encode(e, s.color)
+		} else {
+			line = encodeText(e, flags, s.color)
+		}
+	case FormatJSON:
+		line = encodeJSON(e)
+	case FormatLogfmt:
+		line = encodeLogfmt(e)
+	case FormatConsole:
+		line = s.console.encode(e, s.color)
+	}
+
+	//nolint:wrapcheck // caller wraps
+	return s.l.Output(0, line)
+}
@@ -0,0 +1,196 @@
+// Copyright 2024 Raphael Thomazella. All rights reserved.
+// Use of this source code is governed by the BSD-3-Clause
+// license that can be found in the LICENSE file and online
+// at https://opensource.org/license/BSD-3-clause.
+
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+)
+
+// slog has no fatal level, it's placed after error with the same spacing
+// as the other slog levels.
+const SlogLevelFatal = slog.LevelError + 4
+
+// maps a logging level to a slog level.
+func SlogLevel(level Level) slog.Level {
+	//nolint:exhaustive // default handles LInfo
+	switch level {
+	default:
+		return slog.LevelInfo
+	case LDebug:
+		return slog.LevelDebug
+	case LWarn:
+		return slog.LevelWarn
+	case LError:
+		return slog.LevelError
+	case LFatal:
+		return SlogLevelFatal
+	case LNone:
+		return SlogLevelFatal + 4
+	}
+}
+
+// maps a slog level to a logging level; levels between slog levels are
+// rounded down, i.e. slog.LevelInfo+2 is LInfo.
+func FromSlogLevel(level slog.Level) Level {
+	switch {
+	case level < slog.LevelInfo:
+		return LDebug
+	case level < slog.LevelWarn:
+		return LInfo
+	case level < slog.LevelError:
+		return LWarn
+	case level < SlogLevelFatal:
+		return LError
+	case level < SlogLevelFatal+4:
+		return LFatal
+	default:
+		return LNone
+	}
+}
+
+// a slog.Handler that writes to a Logger. Attributes become data, groups
+// become nested data maps. Records at fatal level are written but the
+// logger exit function is not called.
+type Handler struct {
+	logger *Logger
+	attrs  []groupedAttr
+	groups []string
+}
+
+type groupedAttr struct {
+	groups []string
+	attr   slog.Attr
+}
+
+var _ slog.Handler = (*Handler)(nil)
+
+// creates a slog.Handler sharing the logger's writer and level.
+func NewHandler(logger *Logger) *Handler {
+	return &Handler{logger: logger}
+}
+
+// implementation of slog.Handler.Enabled.
+func (h *Handler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.logger.enabled(FromSlogLevel(level))
+}
+
+// implementation of slog.Handler.Handle.
+func (h *Handler) Handle(_ context.Context, rec slog.Record) error {
+	var data map[string]any
+
+	for _, ga := range h.attrs {
+		data = insertAttr(data, ga.groups, ga.attr)
+	}
+
+	rec.Attrs(func(attr slog.Attr) bool {
+		data = insertAttr(data, h.groups, attr)
+
+		return true
+	})
+
+	t := rec.Time
+	if t.IsZero() {
+		t = h.logger.core.now()
+	}
+
+	h.logger.write(newEntry(t, FromSlogLevel(rec.Level), rec.PC, rec.Message, data))
+
+	return nil
+}
+
+// implementation of slog.Handler.WithAttrs.
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	clone := *h
+	clone.attrs = slices.Clip(clone.attrs)
+
+	for _, attr := range attrs {
+		clone.attrs = append(clone.attrs, groupedAttr{groups: h.groups, attr: attr})
+	}
+
+	return &clone
+}
+
+// implementation of slog.Handler.WithGroup.
+func (h *Handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	clone := *h
+	clone.groups = append(slices.Clip(h.groups), name)
+
+	return &clone
+}
+
+// inserts attr into data nested under groups, allocating maps as needed.
+func insertAttr(data map[string]any, groups []string, attr slog.Attr) map[string]any {
+	attr.Value = attr.Value.Resolve()
+
+	if attr.Equal(slog.Attr{}) {
+		return data
+	}
+
+	if attr.Value.Kind() == slog.KindGroup {
+		group := attr.Value.Group()
+		if len(group) == 0 {
+			return data
+		}
+
+		if attr.Key != "" {
+			groups = append(slices.Clip(groups), attr.Key)
+		}
+
+		for _, a := range group {
+			data = insertAttr(data, groups, a)
+		}
+
+		return data
+	}
+
+	if data == nil {
+		data = map[string]any{}
+	}
+
+	target := data
+
+	for _, g := range groups {
+		nested, ok := target[g].(map[string]any)
+		if !ok {
+			nested = map[string]any{}
+			target[g] = nested
+		}
+
+		target = nested
+	}
+
+	target[attr.Key] = attr.Value.Any()
+
+	return data
+}
+
+// converts the entry to a slog record, data keys are sorted.
+func (e *entry) record() slog.Record {
+	rec := slog.NewRecord(e.time, SlogLevel(e.level), e.message, e.pc)
+	keys := make([]string, 0, len(e.data))
+
+	for key := range e.data {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		rec.AddAttrs(slog.Any(key, e.data[key]))
+	}
+
+	return rec
+}
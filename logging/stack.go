@@ -0,0 +1,165 @@
+// Copyright 2024 Raphael Thomazella. All rights reserved.
+// Use of this source code is governed by the BSD-3-Clause
+// license that can be found in the LICENSE file and online
+// at https://opensource.org/license/BSD-3-clause.
+
+package logging
+
+import (
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// initial size of the buffer passed to runtime.Stack, doubled until the stack fits.
+const stackBufferSize = 4096
+
+// matches the header of a goroutine in runtime.Stack output, like "goroutine 1 [running]:".
+var reGoroutine = regexp.MustCompile(`^goroutine (\d+)(?: .*?)? \[(.*)\]:$`)
+
+// frames of functions in these packages are skipped by StackFrames.
+var stackSkip = []string{"runtime.", "runtime/debug.", "github.com/tcodes0/go/logging."}
+
+// a function call in a stack trace.
+type Frame struct {
+	// package path qualified name, like "main.(*T).Method".
+	Function string `json:"function"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+}
+
+// a goroutine in a stack trace, see ParseStack.
+type Goroutine struct {
+	// the go statement that started the goroutine, nil for the main goroutine.
+	CreatedBy *Frame `json:"createdBy,omitempty"`
+	// why the goroutine is not running and for how long, like "chan receive, 2 minutes".
+	State string `json:"state"`
+	// innermost call first.
+	Frames []Frame `json:"frames"`
+	ID     int     `json:"id"`
+}
+
+// parses a stack trace formatted by runtime.Stack or debug.Stack.
+// Lines that are not understood are ignored.
+func ParseStack(stack []byte) []Goroutine {
+	var (
+		goroutines []Goroutine
+		current    *Goroutine
+		function   string
+		createdBy  bool
+	)
+
+	for _, line := range strings.Split(string(stack), "\n") {
+		if match := reGoroutine.FindStringSubmatch(line); match != nil {
+			id, _ := strconv.Atoi(match[1])
+			goroutines = append(goroutines, Goroutine{ID: id, State: match[2]})
+			current = &goroutines[len(goroutines)-1]
+			function = ""
+
+			continue
+		}
+
+		switch {
+		case current == nil, line == "", strings.HasPrefix(line, "..."):
+		case strings.HasPrefix(line, "\t"):
+			if function == "" {
+				continue
+			}
+
+			frame := parseLocation(strings.TrimPrefix(line, "\t"))
+			frame.Function = function
+
+			if createdBy {
+				current.CreatedBy = &frame
+			} else {
+				current.Frames = append(current.Frames, frame)
+			}
+
+			function = ""
+		case strings.HasPrefix(line, "created by "):
+			function, _, _ = strings.Cut(strings.TrimPrefix(line, "created by "), " in goroutine ")
+			createdBy = true
+		default:
+			function = line
+			createdBy = false
+
+			if i := strings.LastIndexByte(line, '('); i > 0 && strings.HasSuffix(line, ")") {
+				function = line[:i]
+			}
+		}
+	}
+
+	return goroutines
+}
+
+// returns copies of goroutines without frames of functions starting with
+// one of prefixes, like "runtime.".
+func SkipFrames(goroutines []Goroutine, prefixes ...string) []Goroutine {
+	filtered := make([]Goroutine, 0, len(goroutines))
+
+	for _, goroutine := range goroutines {
+		frames := make([]Frame, 0, len(goroutine.Frames))
+
+		for _, frame := range goroutine.Frames {
+			if !hasAnyPrefix(frame.Function, prefixes) {
+				frames = append(frames, frame)
+			}
+		}
+
+		goroutine.Frames = frames
+		filtered = append(filtered, goroutine)
+	}
+
+	return filtered
+}
+
+// prints a stacktrace as a log message with customizable level. Frames are
+// parsed and sent as data under "goroutines", without runtime and logging frames.
+func (logger *Logger) StackFrames(level Level, allGoroutines bool) {
+	if !logger.enabled(level) {
+		return
+	}
+
+	goroutines := SkipFrames(ParseStack(captureStack(allGoroutines)), stackSkip...)
+	logger.out(level, map[string]any{"goroutines": goroutines}, "stacktrace")
+}
+
+// the stack of the current goroutine, or all goroutines, formatted by runtime.Stack.
+func captureStack(all bool) []byte {
+	buf := make([]byte, stackBufferSize)
+
+	for {
+		n := runtime.Stack(buf, all)
+		if n < len(buf) {
+			return buf[:n]
+		}
+
+		buf = make([]byte, 2*len(buf))
+	}
+}
+
+// parses "/path/file.go:12 +0x1d".
+func parseLocation(location string) Frame {
+	location, _, _ = strings.Cut(location, " ")
+	frame := Frame{File: location}
+
+	if i := strings.LastIndexByte(location, ':'); i > 0 {
+		if line, err := strconv.Atoi(location[i+1:]); err == nil {
+			frame.File = location[:i]
+			frame.Line = line
+		}
+	}
+
+	return frame
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,247 @@
+// Copyright 2024 Raphael Thomazella. All rights reserved.
+// Use of this source code is governed by the BSD-3-Clause
+// license that can be found in the LICENSE file and online
+// at https://opensource.org/license/BSD-3-clause.
+
+package logging
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tcodes0/go/hue"
+)
+
+const (
+	// layout used if Template.Layout is not set, fields come before the message.
+	DefaultLayout = "{time} {level} {caller} {fields} {message}"
+	// time format with milliseconds, used if Template.TimeFormat is not set.
+	RFC3339Milli = "2006-01-02T15:04:05.000Z07:00"
+)
+
+// how the level is padded to Template.LevelWidth.
+type Align uint8
+
+const (
+	// pads on the right, the default.
+	AlignLeft Align = iota
+	// pads on the left.
+	AlignRight
+)
+
+// layout of text lines, see OptTemplate. Placeholders in Layout are
+// replaced: {time}, {level}, {caller}, {name} of the logger, {fields} with
+// the line data, and {message}. Empty placeholders remove a following space.
+type Template struct {
+	Layout string
+	// a time.Format layout, like time.RFC3339.
+	TimeFormat string
+	// level names are padded with spaces to this width.
+	LevelWidth int
+	LevelAlign Align
+	// format time in UTC instead of the local time zone.
+	UTC bool
+	// print the caller's full file path instead of the base name.
+	LongCaller bool
+}
+
+// option to format text lines of the writer set with OptWriter using t,
+// instead of log package flags. Sinks set the template in Sink.Template.
+func OptTemplate(t Template) CreateOptions {
+	return func(c *createOpts) {
+		c.template = &t
+	}
+}
+
+// a source of time, implemented by clock.Nower.
+type Nower interface {
+	Now() time.Time
+}
+
+// option to take the time of lines from nower instead of the system clock,
+// i.e. clock.Static for deterministic output in tests.
+func OptClock(nower Nower) CreateOptions {
+	return func(c *createOpts) {
+		c.nower = nower
+	}
+}
+
+type placeholder uint8
+
+const (
+	literal placeholder = iota
+	timePlaceholder
+	levelPlaceholder
+	callerPlaceholder
+	namePlaceholder
+	fieldsPlaceholder
+	messagePlaceholder
+)
+
+var placeholders = map[string]placeholder{
+	"{time}":    timePlaceholder,
+	"{level}":   levelPlaceholder,
+	"{caller}":  callerPlaceholder,
+	"{name}":    namePlaceholder,
+	"{fields}":  fieldsPlaceholder,
+	"{message}": messagePlaceholder,
+}
+
+type templatePart struct {
+	text        string // if literal
+	placeholder placeholder
+}
+
+// a template parsed once per sink.
+type textTemplate struct {
+	parts []templatePart
+	opts  Template
+}
+
+func newTextTemplate(t Template) *textTemplate {
+	if t.Layout == "" {
+		t.Layout = DefaultLayout
+	}
+
+	if t.TimeFormat == "" {
+		t.TimeFormat = RFC3339Milli
+	}
+
+	tmpl := &textTemplate{opts: t}
+	layout := t.Layout
+
+	for layout != "" {
+		start := strings.IndexByte(layout, '{')
+		end := strings.IndexByte(layout[max(start, 0):], '}') + max(start, 0)
+
+		if start == -1 || end < start {
+			tmpl.addLiteral(layout)
+
+			break
+		}
+
+		p, ok := placeholders[layout[start:end+1]]
+		if !ok {
+			tmpl.addLiteral(layout[:end+1])
+			layout = layout[end+1:]
+
+			continue
+		}
+
+		tmpl.addLiteral(layout[:start])
+		tmpl.parts = append(tmpl.parts, templatePart{placeholder: p})
+		layout = layout[end+1:]
+	}
+
+	return tmpl
+}
+
+func (tmpl *textTemplate) addLiteral(text string) {
+	if text == "" {
+		return
+	}
+
+	if n := len(tmpl.parts); n > 0 && tmpl.parts[n-1].placeholder == literal {
+		tmpl.parts[n-1].text += text
+
+		return
+	}
+
+	tmpl.parts = append(tmpl.parts, templatePart{text: text})
+}
+
+// encodes the entry following the template.
+func (tmpl *textTemplate) encode(e *entry, color bool) string {
+	var (
+		buf       strings.Builder
+		skipSpace bool
+	)
+
+	for i, part := range tmpl.parts {
+		text := part.text
+		if part.placeholder != literal {
+			text = tmpl.render(part.placeholder, e, color)
+		}
+
+		if skipSpace {
+			text = strings.TrimPrefix(text, " ")
+		}
+
+		skipSpace = part.placeholder != literal && text == ""
+
+		// an empty placeholder at the end removes the space before it
+		if skipSpace && i == len(tmpl.parts)-1 {
+			trimmed := strings.TrimSuffix(buf.String(), " ")
+			buf.Reset()
+			buf.WriteString(trimmed)
+		}
+
+		buf.WriteString(text)
+	}
+
+	return buf.String()
+}
+
+func (tmpl *textTemplate) render(p placeholder, e *entry, color bool) string {
+	//nolint:exhaustive // literals are not rendered
+	switch p {
+	case timePlaceholder:
+		t := e.time.Local()
+		if tmpl.opts.UTC {
+			t = t.UTC()
+		}
+
+		return t.Format(tmpl.opts.TimeFormat)
+	case levelPlaceholder:
+		return tmpl.level(e.level, color)
+	case callerPlaceholder:
+		if tmpl.opts.LongCaller {
+			return e.file + ":" + strconv.Itoa(e.line)
+		}
+
+		return e.caller()
+	case namePlaceholder:
+		return e.name
+	case fieldsPlaceholder:
+		return strings.TrimSuffix(formatData(e.data, color), " ")
+	case messagePlaceholder:
+		return e.message
+	}
+
+	return ""
+}
+
+func (tmpl *textTemplate) level(level Level, color bool) string {
+	name := strings.ToUpper(level.String())
+
+	if pad := tmpl.opts.LevelWidth - len(name); pad > 0 {
+		if tmpl.opts.LevelAlign == AlignRight {
+			name = strings.Repeat(" ", pad) + name
+		} else {
+			name += strings.Repeat(" ", pad)
+		}
+	}
+
+	if !color {
+		return name
+	}
+
+	return hue.Printc(levelColor(level), name, hue.End)
+}
+
+func levelColor(level Level) int {
+	//nolint:exhaustive // default handles LInfo
+	switch level {
+	default:
+		return hue.Gray
+	case LWarn:
+		return hue.Yellow
+	case LError:
+		return hue.Red
+	case LFatal:
+		return hue.BrightRed
+	case LDebug:
+		return hue.Blue
+	}
+}